@@ -0,0 +1,62 @@
+package rsacrypto
+
+import (
+	"errors"
+	"strings"
+)
+
+// Errors returned by this package, they could be matched with errors.Is .
+var (
+	ErrNoKey           = errors.New("rsacrypto: key is not set")
+	ErrNoSignerOpts    = errors.New("rsacrypto: signer options are not set")
+	ErrUnsupportedOpts = errors.New("rsacrypto: unsupported options")
+	ErrKeyFormat       = errors.New("rsacrypto: unsupported key format")
+	ErrNotRSAKey       = errors.New("rsacrypto: not a rsa key")
+	ErrChunkSize       = errors.New("rsacrypto: invalid chunk size")
+	ErrDigestSize      = errors.New("rsacrypto: invalid digest size")
+	ErrPassword        = errors.New("rsacrypto: missing or incorrect password")
+	ErrCertificate     = errors.New("rsacrypto: invalid certificate")
+)
+
+// The error of parsing a key in one format.
+type FormatError struct {
+	Format KeyFormat
+	Err    error
+}
+
+func (e *FormatError) Error() string {
+	return e.Format.String() + ": " + e.Err.Error()
+}
+
+func (e *FormatError) Unwrap() error {
+	return e.Err
+}
+
+// Returned when data could not be parsed as a key in any of the attempted formats.
+//		errors.Is(err, ErrKeyFormat) reports true for it,
+//		and every *FormatError is reachable with errors.As .
+type KeyParseError struct {
+	Errs []*FormatError // One for every attempted format, in the order of attempts.
+}
+
+func (e *KeyParseError) Error() string {
+	b := strings.Builder{}
+	b.WriteString("rsacrypto: failed to parse key")
+	for _, err := range e.Errs {
+		b.WriteString("\n")
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+func (e *KeyParseError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errs))
+	for _, err := range e.Errs {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+func (e *KeyParseError) Is(target error) bool {
+	return target == ErrKeyFormat
+}
@@ -0,0 +1,185 @@
+package rsacrypto
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// The default validity of certificates created by CreateSelfSignedCertificate.
+const DefaultCertificateValidity = 365 * 24 * time.Hour
+
+// Options to check a certificate, @see VerifyCertificate .
+type CertificateOptions struct {
+	Roots         *x509.CertPool     // Verify the chain against Roots if not nil, otherwise the chain is not verified.
+	Intermediates *x509.CertPool     // Extra intermediates, the certificates following the leaf are always used.
+	CurrentTime   time.Time          // Check the validity dates at this time, zero means now.
+	KeyUsage      x509.KeyUsage      // The required key usage bits, e.g. x509.KeyUsageKeyEncipherment, zero skips the check.
+	ExtKeyUsages  []x509.ExtKeyUsage // The accepted extended key usages of the chain, empty means any.
+}
+
+// Parse certificates from PEM data (CERTIFICATE blocks, other blocks are skipped) or DER data.
+func ParseCertificates(data []byte) (certs []*x509.Certificate, err error) {
+	rest := data
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != PEMTypeCertificate {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) > 0 {
+		return certs, nil
+	}
+
+	certs, err = x509.ParseCertificates(data)
+	if err != nil || len(certs) == 0 {
+		return nil, fmt.Errorf("%w, no certificate found", ErrKeyFormat)
+	}
+	return certs, nil
+}
+
+// Parse the rsa public key of the first certificate in PEM or DER data, @see ParseCertificates .
+//		The following certificates are used as intermediates.
+//		If opts is not nil, the certificate is checked by VerifyCertificate first.
+func ParseCertificatePublicKey(data []byte, opts *CertificateOptions) (key *rsa.PublicKey, cert *x509.Certificate, err error) {
+	certs, err := ParseCertificates(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if opts != nil {
+		if err := VerifyCertificate(certs[0], certs[1:], opts); err != nil {
+			return nil, nil, err
+		}
+	}
+	key, err = certificatePublicKey(certs[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, certs[0], nil
+}
+
+// Check the validity dates and key usage of cert, and verify its chain if opts.Roots is not nil.
+//		An invalid certificate returns an error matching ErrCertificate,
+//		the x509 error, e.g. x509.CertificateInvalidError, is reachable with errors.As .
+//		Nil opts only check the validity dates at the current time.
+func VerifyCertificate(cert *x509.Certificate, intermediates []*x509.Certificate, opts *CertificateOptions) error {
+	if cert == nil {
+		return fmt.Errorf("%w, certificate is nil", ErrCertificate)
+	}
+	if opts == nil {
+		opts = &CertificateOptions{}
+	}
+	now := opts.CurrentTime
+	if now.IsZero() {
+		now = time.Now()
+	}
+	if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return fmt.Errorf("%w, %w", ErrCertificate, x509.CertificateInvalidError{Cert: cert, Reason: x509.Expired})
+	}
+	if opts.KeyUsage != 0 && cert.KeyUsage != 0 && cert.KeyUsage&opts.KeyUsage != opts.KeyUsage {
+		return fmt.Errorf("%w, certificate key usage %#x does not allow %#x", ErrCertificate, cert.KeyUsage, opts.KeyUsage)
+	}
+
+	if opts.Roots == nil {
+		return nil
+	}
+	pool := opts.Intermediates
+	if len(intermediates) > 0 {
+		if pool == nil {
+			pool = x509.NewCertPool()
+		} else {
+			pool = pool.Clone()
+		}
+		for _, intermediate := range intermediates {
+			pool.AddCert(intermediate)
+		}
+	}
+	keyUsages := opts.ExtKeyUsages
+	if len(keyUsages) == 0 {
+		keyUsages = []x509.ExtKeyUsage{x509.ExtKeyUsageAny}
+	}
+	_, err := cert.Verify(x509.VerifyOptions{
+		Roots:         opts.Roots,
+		Intermediates: pool,
+		CurrentTime:   now,
+		KeyUsages:     keyUsages,
+	})
+	if err != nil {
+		return fmt.Errorf("%w, %w", ErrCertificate, err)
+	}
+	return nil
+}
+
+// Create a PKCS10 certificate signing request signed by key, the result is DER data.
+//		The signature algorithm defaults to SHA256WithRSA, a nil template is the same as an empty one.
+func CreateCertificateRequest(key *rsa.PrivateKey, template *x509.CertificateRequest) (der []byte, err error) {
+	csr := x509.CertificateRequest{}
+	if template != nil {
+		csr = *template
+	}
+	if csr.SignatureAlgorithm == x509.UnknownSignatureAlgorithm {
+		csr.SignatureAlgorithm = x509.SHA256WithRSA
+	}
+	return x509.CreateCertificateRequest(rand.Reader, &csr, key)
+}
+
+// Create a certificate of key signed by itself, the result is DER data.
+//		Empty fields of template get defaults: a random serial number, a validity
+//		of DefaultCertificateValidity from now, the digital signature and key encipherment
+//		key usages (and certificate signing for a CA), and the SHA256WithRSA signature algorithm.
+//		A nil template is the same as an empty one.
+func CreateSelfSignedCertificate(key *rsa.PrivateKey, template *x509.Certificate) (der []byte, err error) {
+	cert := x509.Certificate{}
+	if template != nil {
+		cert = *template
+	}
+	if cert.SerialNumber == nil {
+		cert.SerialNumber, err = rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if cert.NotBefore.IsZero() {
+		cert.NotBefore = time.Now()
+	}
+	if cert.NotAfter.IsZero() {
+		cert.NotAfter = cert.NotBefore.Add(DefaultCertificateValidity)
+	}
+	if cert.KeyUsage == 0 {
+		cert.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
+		if cert.IsCA {
+			cert.KeyUsage |= x509.KeyUsageCertSign
+		}
+	}
+	if cert.IsCA {
+		cert.BasicConstraintsValid = true
+	}
+	if cert.SignatureAlgorithm == x509.UnknownSignatureAlgorithm {
+		cert.SignatureAlgorithm = x509.SHA256WithRSA
+	}
+	return x509.CreateCertificate(rand.Reader, &cert, &cert, &key.PublicKey, key)
+}
+
+func certificatePublicKey(cert *x509.Certificate) (*rsa.PublicKey, error) {
+	if cert == nil {
+		return nil, fmt.Errorf("%w, certificate is nil", ErrCertificate)
+	}
+	key, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%w, certificate public key is %T", ErrNotRSAKey, cert.PublicKey)
+	}
+	return key, nil
+}
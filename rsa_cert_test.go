@@ -0,0 +1,138 @@
+package rsacrypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestParseCertificatePublicKey(t *testing.T) {
+	caKey, err := NewRSAPrivateKey().SetEncodedKey(testKeys[0].PrivateKey, nil)
+	assert.Nil(t, err)
+	leafKey, err := NewRSAPrivateKey().SetEncodedKey(testKeys[1].PrivateKey, nil)
+	assert.Nil(t, err)
+
+	caDer, err := caKey.CreateSelfSignedCertificate(&x509.Certificate{Subject: pkix.Name{CommonName: "rsacrypto test CA"}, IsCA: true})
+	assert.Nil(t, err)
+	ca, err := x509.ParseCertificate(caDer)
+	assert.Nil(t, err)
+	assert.True(t, ca.IsCA)
+	assert.NotZero(t, ca.KeyUsage&x509.KeyUsageCertSign)
+
+	leafDer, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "rsacrypto test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}, ca, &leafKey.privateKey.PublicKey, caKey.privateKey)
+	assert.Nil(t, err)
+	chain := append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leafDer}), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDer})...)
+
+	certs, err := ParseCertificates(chain)
+	assert.Nil(t, err)
+	assert.Len(t, certs, 2)
+	certs, err = ParseCertificates(append(leafDer, caDer...))
+	assert.Nil(t, err)
+	assert.Len(t, certs, 2)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	key, cert, err := ParseCertificatePublicKey(chain, &CertificateOptions{Roots: roots, KeyUsage: x509.KeyUsageDigitalSignature})
+	assert.Nil(t, err)
+	assert.Equal(t, "rsacrypto test", cert.Subject.CommonName)
+	assert.True(t, leafKey.privateKey.PublicKey.Equal(key))
+
+	// Without options nothing is checked.
+	_, _, err = ParseCertificatePublicKey(leafDer, nil)
+	assert.Nil(t, err)
+
+	_, _, err = ParseCertificatePublicKey(chain, &CertificateOptions{Roots: x509.NewCertPool()})
+	assert.ErrorIs(t, err, ErrCertificate)
+	assert.True(t, errors.As(err, &x509.UnknownAuthorityError{}))
+
+	_, _, err = ParseCertificatePublicKey(chain, &CertificateOptions{CurrentTime: time.Now().Add(2 * time.Hour)})
+	assert.ErrorIs(t, err, ErrCertificate)
+	assert.True(t, errors.As(err, &x509.CertificateInvalidError{}))
+
+	_, _, err = ParseCertificatePublicKey(chain, &CertificateOptions{KeyUsage: x509.KeyUsageKeyEncipherment})
+	assert.ErrorIs(t, err, ErrCertificate)
+
+	_, err = ParseCertificates([]byte("not a certificate"))
+	assert.ErrorIs(t, err, ErrKeyFormat)
+
+	// Nil options only check the validity dates.
+	assert.Nil(t, VerifyCertificate(cert, nil, nil))
+	assert.ErrorIs(t, VerifyCertificate(nil, nil, nil), ErrCertificate)
+}
+
+func TestRSAPublicKey_SetCertificate(t *testing.T) {
+	privKey, err := NewRSAPrivateKey().SetEncodedKey(testKeys[0].PrivateKey, nil)
+	assert.Nil(t, err)
+	der, err := privKey.CreateSelfSignedCertificate(&x509.Certificate{Subject: pkix.Name{CommonName: "rsacrypto test"}})
+	assert.Nil(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.Nil(t, err)
+	assert.Equal(t, x509.KeyUsageDigitalSignature|x509.KeyUsageKeyEncipherment, cert.KeyUsage)
+
+	pubKey, err := NewRSAPublicKey().SetCertificate(cert)
+	assert.Nil(t, err)
+	cipher, err := pubKey.Encrypt([]byte("A short message"))
+	assert.Nil(t, err)
+	plain, err := privKey.Decrypt(cipher)
+	assert.Nil(t, err)
+	assert.Equal(t, "A short message", string(plain))
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), NotAfter: time.Now().Add(time.Hour)}
+	ecDer, err := x509.CreateCertificate(rand.Reader, template, template, &ecKey.PublicKey, ecKey)
+	assert.Nil(t, err)
+	ecCert, err := x509.ParseCertificate(ecDer)
+	assert.Nil(t, err)
+	_, err = NewRSAPublicKey().SetCertificate(ecCert)
+	assert.ErrorIs(t, err, ErrNotRSAKey)
+	_, err = NewRSAPublicKey().SetCertificate(nil)
+	assert.ErrorIs(t, err, ErrCertificate)
+}
+
+func TestRSAPrivateKey_CreateCertificateRequest(t *testing.T) {
+	privKey, err := NewRSAPrivateKey().SetEncodedKey(testKeys[0].PrivateKey, nil)
+	assert.Nil(t, err)
+
+	der, err := privKey.CreateCertificateRequest(&x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: "rsacrypto test"},
+		DNSNames: []string{"rsacrypto.test"},
+	})
+	assert.Nil(t, err)
+	csr, err := x509.ParseCertificateRequest(der)
+	assert.Nil(t, err)
+	assert.Nil(t, csr.CheckSignature())
+	assert.Equal(t, x509.SHA256WithRSA, csr.SignatureAlgorithm)
+	assert.Equal(t, []string{"rsacrypto.test"}, csr.DNSNames)
+	assert.True(t, privKey.privateKey.PublicKey.Equal(csr.PublicKey))
+
+	// A nil template is the same as an empty one.
+	der, err = privKey.CreateCertificateRequest(nil)
+	assert.Nil(t, err)
+	csr, err = x509.ParseCertificateRequest(der)
+	assert.Nil(t, err)
+	assert.Nil(t, csr.CheckSignature())
+	der, err = privKey.CreateSelfSignedCertificate(nil)
+	assert.Nil(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.Nil(t, err)
+	assert.Nil(t, cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature))
+	assert.Equal(t, x509.KeyUsageDigitalSignature|x509.KeyUsageKeyEncipherment, cert.KeyUsage)
+
+	_, err = NewRSAPrivateKey().CreateCertificateRequest(&x509.CertificateRequest{})
+	assert.ErrorIs(t, err, ErrNoKey)
+}
@@ -0,0 +1,173 @@
+package rsacrypto
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// The raw components of a rsa private key, big-endian unsigned integers.
+//		N, E and D are required. P and Q are recovered from them when both are absent.
+//		DP, DQ and QInv are optional, they are checked against the key when present.
+type PrivateKeyComponents struct {
+	N, E, D      []byte
+	P, Q         []byte
+	DP, DQ, QInv []byte
+}
+
+// A member of an encoded private key, e.g. a JWK or RSAKeyValue member, and the component it is decoded into.
+type componentMember struct {
+	name, value string
+	b           *[]byte
+}
+
+// Decode the present members into their components with decode, blank members are left empty.
+func decodeComponentMembers(members []componentMember, decode func(name string, s string) (*big.Int, error)) error {
+	for _, member := range members {
+		if strings.TrimSpace(member.value) == "" {
+			continue
+		}
+		i, err := decode(member.name, member.value)
+		if err != nil {
+			return err
+		}
+		*member.b = i.Bytes()
+	}
+	return nil
+}
+
+// The smallest modulus accepted from components, the same as crypto/rsa since Go 1.24 .
+const minModulusBits = 1024
+
+// Create rsa public key from the big-endian modulus and public exponent.
+//		The modulus must be odd and at least 1024 bits.
+func NewPublicKeyFromComponents(modulus []byte, exponent []byte) (key *rsa.PublicKey, err error) {
+	n := new(big.Int).SetBytes(modulus)
+	if n.Sign() == 0 || n.Bit(0) == 0 {
+		return nil, fmt.Errorf("%w, invalid modulus", ErrKeyFormat)
+	}
+	if n.BitLen() < minModulusBits {
+		return nil, fmt.Errorf("%w, modulus of %d bits is smaller than %d bits", ErrKeyFormat, n.BitLen(), minModulusBits)
+	}
+	e := new(big.Int).SetBytes(exponent)
+	if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 || e.Bit(0) == 0 {
+		return nil, fmt.Errorf("%w, invalid public exponent", ErrKeyFormat)
+	}
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+// Same as NewPublicKeyFromComponents, but the components are decoded by encoding first,
+// e.g. HexEncoding or base64.StdEncoding. A nil encoding means base64.StdEncoding .
+func NewPublicKeyFromEncodedComponents(modulus string, exponent string, encoding Encoding) (key *rsa.PublicKey, err error) {
+	if encoding == nil {
+		encoding = base64.StdEncoding
+	}
+
+	n, err := encoding.DecodeString(modulus)
+	if err != nil {
+		return nil, err
+	}
+	e, err := encoding.DecodeString(exponent)
+	if err != nil {
+		return nil, err
+	}
+	return NewPublicKeyFromComponents(n, e)
+}
+
+// Create rsa private key from its components, @see PrivateKeyComponents .
+//		The key is validated before it is returned.
+func NewPrivateKeyFromComponents(components *PrivateKeyComponents) (key *rsa.PrivateKey, err error) {
+	pub, err := NewPublicKeyFromComponents(components.N, components.E)
+	if err != nil {
+		return nil, err
+	}
+	d := new(big.Int).SetBytes(components.D)
+	if d.Sign() == 0 {
+		return nil, fmt.Errorf("%w, missing private exponent", ErrKeyFormat)
+	}
+
+	var p, q *big.Int
+	switch {
+	case len(components.P) > 0 && len(components.Q) > 0:
+		p, q = new(big.Int).SetBytes(components.P), new(big.Int).SetBytes(components.Q)
+	case len(components.P) == 0 && len(components.Q) == 0:
+		if p, q, err = recoverPrimes(pub.N, pub.E, d); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w, only one of the primes is given", ErrKeyFormat)
+	}
+
+	key = &rsa.PrivateKey{PublicKey: *pub, D: d, Primes: []*big.Int{p, q}}
+	if err := key.Validate(); err != nil {
+		return nil, fmt.Errorf("%w, invalid private key: %v", ErrKeyFormat, err)
+	}
+	key.Precompute()
+	for _, pair := range []struct {
+		given       []byte
+		precomputed *big.Int
+	}{
+		{components.DP, key.Precomputed.Dp},
+		{components.DQ, key.Precomputed.Dq},
+		{components.QInv, key.Precomputed.Qinv},
+	} {
+		if len(pair.given) > 0 && new(big.Int).SetBytes(pair.given).Cmp(pair.precomputed) != 0 {
+			return nil, fmt.Errorf("%w, CRT values do not match the key", ErrKeyFormat)
+		}
+	}
+	return key, nil
+}
+
+// The CRT values dp, dq and qinv of a two primes key.
+//		They are computed into new integers, key.Precompute() would modify a key the caller may share.
+func crtValues(key *rsa.PrivateKey) (dp *big.Int, dq *big.Int, qinv *big.Int) {
+	one := big.NewInt(1)
+	p, q := key.Primes[0], key.Primes[1]
+	dp = new(big.Int).Mod(key.D, new(big.Int).Sub(p, one))
+	dq = new(big.Int).Mod(key.D, new(big.Int).Sub(q, one))
+	qinv = new(big.Int).ModInverse(q, p)
+	return dp, dq, qinv
+}
+
+// Recover the primes p, q of n from the exponents e and d,
+// @see https://nvlpubs.nist.gov/nistpubs/SpecialPublications/NIST.SP.800-56Br2.pdf appendix C.
+//		e*d-1 is a multiple of lambda(n), so for most g a square root of 1 other than ±1
+//		shows up in g^r, g^2r, ..., where e*d-1 = 2^t*r and r is odd.
+func recoverPrimes(n *big.Int, e int, d *big.Int) (p *big.Int, q *big.Int, err error) {
+	one := big.NewInt(1)
+	nMinusOne := new(big.Int).Sub(n, one)
+
+	k := new(big.Int).Mul(d, big.NewInt(int64(e)))
+	k.Sub(k, one)
+	if k.Sign() <= 0 || k.Bit(0) != 0 {
+		return nil, nil, fmt.Errorf("%w, inconsistent public and private exponents", ErrKeyFormat)
+	}
+	t := k.TrailingZeroBits()
+	r := new(big.Int).Rsh(k, t)
+
+	y, x := new(big.Int), new(big.Int)
+	for g := int64(2); g < 102; g++ {
+		y.Exp(big.NewInt(g), r, n)
+		if y.Cmp(one) == 0 || y.Cmp(nMinusOne) == 0 {
+			continue
+		}
+		for i := uint(0); i < t; i++ {
+			x.Exp(y, big.NewInt(2), n)
+			if x.Cmp(one) == 0 {
+				p = new(big.Int).GCD(nil, nil, y.Sub(y, one), n)
+				q = new(big.Int).Div(n, p)
+				if p.Cmp(q) < 0 {
+					p, q = q, p
+				}
+				return p, q, nil
+			}
+			if x.Cmp(nMinusOne) == 0 {
+				break
+			}
+			y.Set(x)
+		}
+	}
+	return nil, nil, fmt.Errorf("%w, could not recover the primes from n, e and d", ErrKeyFormat)
+}
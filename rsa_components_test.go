@@ -0,0 +1,74 @@
+package rsacrypto
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+func TestNewPublicKeyFromComponents(t *testing.T) {
+	for _, key := range testKeys {
+		der, err := base64.StdEncoding.DecodeString(key.PublicKey)
+		assert.Nil(t, err)
+		expected, err := ParseDERPublicKey(der)
+		assert.Nil(t, err)
+
+		pub, err := NewPublicKeyFromComponents(expected.N.Bytes(), big.NewInt(int64(expected.E)).Bytes())
+		assert.Nil(t, err)
+		assert.Equal(t, expected, pub)
+		_, err = NewPublicKeyFromComponents(expected.N.Bytes(), []byte{2})
+		assert.ErrorIs(t, err, ErrKeyFormat)
+
+		pub, err = NewPublicKeyFromEncodedComponents(hex.EncodeToString(expected.N.Bytes()), "010001", HexEncoding)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, pub)
+		pub, err = NewPublicKeyFromEncodedComponents(base64.StdEncoding.EncodeToString(expected.N.Bytes()), "AQAB", nil)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, pub)
+	}
+
+	_, err := NewPublicKeyFromComponents(nil, []byte{1, 0, 1})
+	assert.ErrorIs(t, err, ErrKeyFormat)
+	_, err = NewPublicKeyFromComponents([]byte{0x0f}, []byte{3})
+	assert.ErrorIs(t, err, ErrKeyFormat)
+	_, err = NewPublicKeyFromEncodedComponents("not hex", "010001", HexEncoding)
+	assert.NotNil(t, err)
+}
+
+func TestNewPrivateKeyFromComponents(t *testing.T) {
+	for _, key := range testKeys {
+		expected, err := ParseEncodedPrivateKey(key.PrivateKey, nil)
+		assert.Nil(t, err)
+		e := big.NewInt(int64(expected.E)).Bytes()
+
+		// Full CRT parameters.
+		priv, err := NewPrivateKeyFromComponents(&PrivateKeyComponents{
+			N: expected.N.Bytes(), E: e, D: expected.D.Bytes(),
+			P: expected.Primes[0].Bytes(), Q: expected.Primes[1].Bytes(),
+			DP: expected.Precomputed.Dp.Bytes(), DQ: expected.Precomputed.Dq.Bytes(), QInv: expected.Precomputed.Qinv.Bytes(),
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, expected.Primes, priv.Primes)
+
+		// The primes are recovered from n, e, d.
+		priv, err = NewPrivateKeyFromComponents(&PrivateKeyComponents{N: expected.N.Bytes(), E: e, D: expected.D.Bytes()})
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, expected.Primes, priv.Primes)
+
+		cipher, err := NewRSAPublicKey().SetKey(&expected.PublicKey).Encrypt([]byte("A short message"))
+		assert.Nil(t, err)
+		plain, err := NewRSAPrivateKey().SetKey(priv).Decrypt(cipher)
+		assert.Nil(t, err)
+		assert.Equal(t, "A short message", string(plain))
+
+		// Inconsistent components.
+		_, err = NewPrivateKeyFromComponents(&PrivateKeyComponents{N: expected.N.Bytes(), E: e, D: expected.D.Bytes(), P: expected.Primes[0].Bytes()})
+		assert.ErrorIs(t, err, ErrKeyFormat)
+		_, err = NewPrivateKeyFromComponents(&PrivateKeyComponents{N: expected.N.Bytes(), E: e, D: expected.D.Bytes(), DP: []byte{1}})
+		assert.ErrorIs(t, err, ErrKeyFormat)
+		_, err = NewPrivateKeyFromComponents(&PrivateKeyComponents{N: expected.N.Bytes(), E: []byte{3}, D: expected.D.Bytes()})
+		assert.ErrorIs(t, err, ErrKeyFormat)
+	}
+}
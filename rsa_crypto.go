@@ -2,10 +2,14 @@ package rsacrypto
 
 import (
 	"bytes"
+	"context"
 	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
-	"errors"
+	"fmt"
+	"hash"
+	"sync"
+	"sync/atomic"
 )
 
 type EncrypterOpts interface{}
@@ -13,6 +17,7 @@ type EncrypterOpts interface{}
 type RSAEncrypter struct {
 	publicKey *rsa.PublicKey
 	opts      EncrypterOpts
+	workers   int
 }
 
 func NewRSAEncrypter(publicKey *rsa.PublicKey, opts EncrypterOpts) *RSAEncrypter {
@@ -22,42 +27,69 @@ func NewRSAEncrypter(publicKey *rsa.PublicKey, opts EncrypterOpts) *RSAEncrypter
 	}
 }
 
+// Encrypt chunks with up to n goroutines, chunks are encrypted one by one if n <= 1.
+func (enc *RSAEncrypter) WithWorkers(n int) *RSAEncrypter {
+	enc.workers = n
+	return enc
+}
+
 func (enc *RSAEncrypter) Encrypt(plain []byte) (cipher []byte, err error) {
+	return enc.EncryptContext(context.Background(), plain)
+}
+
+// Same as Encrypt, but stops between chunks and returns ctx.Err() once ctx is done.
+func (enc *RSAEncrypter) EncryptContext(ctx context.Context, plain []byte) (cipher []byte, err error) {
+	if opts, ok := enc.opts.(*HybridOptions); ok {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return encryptHybrid(enc.publicKey, plain, opts)
+	}
+	if opts, ok := enc.opts.(*FramedOptions); ok {
+		return encryptFramed(ctx, enc.publicKey, plain, opts, enc.workers)
+	}
+
 	// RSA algorithm has a limit to the plain message,
 	// so we need to divide the message into chunks first,
 	// then encrypt every chunk.
 	// @see https://en.wikipedia.org/wiki/RSA_(cryptosystem)
-	if enc.opts == nil {
+	limit, err := enc.chunkSize()
+	if err != nil {
+		return nil, err
+	}
+	chunks := split(plain, limit)
+	encryptedChunks, err := processChunks(ctx, chunks, enc.workers, func(_ int, chunk []byte) ([]byte, error) {
+		return enc.encryptChunk(chunk)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bytes.Join(encryptedChunks, nil), nil
+}
+
+// The max size of a plain chunk for the chunked modes.
+func (enc *RSAEncrypter) chunkSize() (int, error) {
+	var limit int
+	switch opts := enc.opts.(type) {
+	case nil:
 		// PKCS1v15
-		limit := enc.publicKey.Size() - 11
-		chunks := split(plain, limit)
-		buffer := bytes.NewBufferString("")
-		for _, chunk := range chunks {
-			encryptedChunk, err := rsa.EncryptPKCS1v15(rand.Reader, enc.publicKey, chunk)
-			if err != nil {
-				return nil, err
-			}
-			buffer.Write(encryptedChunk)
-		}
-		return buffer.Bytes(), nil
-	} else {
-		switch opts := enc.opts.(type) {
-		case *rsa.OAEPOptions:
-			limit := enc.publicKey.Size() - opts.Hash.Size()*2 - 2
-			chunks := split(plain, limit)
-			buffer := bytes.NewBufferString("")
-			for _, chunk := range chunks {
-				encryptedChunk, err := rsa.EncryptOAEP(opts.Hash.New(), rand.Reader, enc.publicKey, chunk, opts.Label)
-				if err != nil {
-					return nil, err
-				}
-				buffer.Write(encryptedChunk)
-			}
-			return buffer.Bytes(), nil
-		default:
-			return nil, errors.New("rsacrypto: invalid options for encrypt")
-		}
+		limit = enc.publicKey.Size() - 11
+	case *rsa.OAEPOptions:
+		limit = enc.publicKey.Size() - opts.Hash.Size()*2 - 2
+	default:
+		return 0, fmt.Errorf("%w %T for encrypt", ErrUnsupportedOpts, enc.opts)
+	}
+	if limit <= 0 {
+		return 0, fmt.Errorf("%w %d, the key is too small for the options", ErrChunkSize, limit)
+	}
+	return limit, nil
+}
+
+func (enc *RSAEncrypter) encryptChunk(chunk []byte) ([]byte, error) {
+	if opts, ok := enc.opts.(*rsa.OAEPOptions); ok {
+		return rsa.EncryptOAEP(opts.Hash.New(), rand.Reader, enc.publicKey, chunk, opts.Label)
 	}
+	return rsa.EncryptPKCS1v15(rand.Reader, enc.publicKey, chunk)
 }
 
 type DecrypterOpts interface{}
@@ -65,6 +97,7 @@ type DecrypterOpts interface{}
 type RSADecrypter struct {
 	privateKey *rsa.PrivateKey
 	opts       DecrypterOpts
+	workers    int
 }
 
 func NewRSADecrypter(privateKey *rsa.PrivateKey, opts DecrypterOpts) *RSADecrypter {
@@ -74,18 +107,65 @@ func NewRSADecrypter(privateKey *rsa.PrivateKey, opts DecrypterOpts) *RSADecrypt
 	}
 }
 
+// Decrypt chunks with up to n goroutines, chunks are decrypted one by one if n <= 1.
+func (dec *RSADecrypter) WithWorkers(n int) *RSADecrypter {
+	dec.workers = n
+	return dec
+}
+
 func (dec *RSADecrypter) Decrypt(cipher []byte) (plain []byte, err error) {
-	limit := dec.privateKey.Size()
-	chunks := split(cipher, limit)
-	buffer := bytes.NewBufferString("")
-	for _, chunk := range chunks {
-		decryptedChunk, err := dec.privateKey.Decrypt(rand.Reader, chunk, dec.opts)
-		if err != nil {
+	return dec.DecryptContext(context.Background(), cipher)
+}
+
+// Same as Decrypt, but stops between chunks and returns ctx.Err() once ctx is done.
+//		With nil options a hybrid ciphertext is detected by its header, @see HybridOptions .
+//		With HybridOptions a ciphertext without the header is rejected,
+//		other options never decrypt a hybrid ciphertext, so their hash and label are always applied.
+func (dec *RSADecrypter) DecryptContext(ctx context.Context, cipher []byte) (plain []byte, err error) {
+	if opts, ok := dec.opts.(*HybridOptions); ok {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return decryptHybrid(dec.privateKey, cipher, opts)
+	}
+	if dec.opts == nil && hasHybridHeader(cipher) {
+		if err := ctx.Err(); err != nil {
 			return nil, err
 		}
-		buffer.Write(decryptedChunk)
+		plain, err := decryptHybrid(dec.privateKey, cipher, nil)
+		// A chunked cipher starts with the hybrid magic by chance once in 2^32, so it is still tried as chunked.
+		if err == nil || len(cipher)%dec.privateKey.Size() != 0 {
+			return plain, err
+		}
+		if plain, chunkedErr := dec.decryptChunked(ctx, cipher); chunkedErr == nil {
+			return plain, nil
+		}
+		return nil, err
+	}
+	return dec.decryptChunked(ctx, cipher)
+}
+
+func (dec *RSADecrypter) decryptChunked(ctx context.Context, cipher []byte) ([]byte, error) {
+	if opts, ok := dec.opts.(*FramedOptions); ok {
+		return decryptFramed(ctx, dec.privateKey, cipher, opts, dec.workers)
+	}
+
+	limit := dec.privateKey.Size()
+	if len(cipher)%limit != 0 {
+		return nil, fmt.Errorf("%w, chunked cipher size %d is not a multiple of %d", ErrChunkSize, len(cipher), limit)
+	}
+	chunks := split(cipher, limit)
+	decryptedChunks, err := processChunks(ctx, chunks, dec.workers, func(_ int, chunk []byte) ([]byte, error) {
+		return dec.decryptChunk(chunk)
+	})
+	if err != nil {
+		return nil, err
 	}
-	return buffer.Bytes(), nil
+	return bytes.Join(decryptedChunks, nil), nil
+}
+
+func (dec *RSADecrypter) decryptChunk(chunk []byte) ([]byte, error) {
+	return dec.privateKey.Decrypt(rand.Reader, chunk, dec.opts)
 }
 
 type DefaultSignerOpts struct {
@@ -96,6 +176,61 @@ func (opts *DefaultSignerOpts) HashFunc() crypto.Hash {
 	return opts.Hash
 }
 
+// The signature scheme of signer options, @see NewSignerOpts .
+type SignerScheme int
+
+const (
+	SchemePKCS1v15 SignerScheme = iota // RSASSA-PKCS1-v1_5, the default.
+	SchemePSS                          // RSASSA-PSS, the salt length is the hash size.
+)
+
+func (s SignerScheme) String() string {
+	switch s {
+	case SchemePKCS1v15:
+		return "PKCS1v15"
+	case SchemePSS:
+		return "PSS"
+	default:
+		return "unknown"
+	}
+}
+
+// The salt lengths of RSASSA-PSS, any other positive value is an exact salt length, @see RSAPrivateKey.SetPSS .
+const (
+	PSSSaltLengthAuto       = rsa.PSSSaltLengthAuto       // Sign with the largest salt, verify with any salt length.
+	PSSSaltLengthEqualsHash = rsa.PSSSaltLengthEqualsHash // The hash size, required by JWS and used by most implementations.
+)
+
+// Create signer options of scheme with hash.
+//		Sign and Verify return ErrUnsupportedOpts with the options of an unknown scheme.
+func NewSignerOpts(scheme SignerScheme, hash crypto.Hash) crypto.SignerOpts {
+	switch scheme {
+	case SchemePKCS1v15:
+		return &DefaultSignerOpts{Hash: hash}
+	case SchemePSS:
+		return &rsa.PSSOptions{Hash: hash, SaltLength: PSSSaltLengthEqualsHash}
+	default:
+		return &unknownSchemeOpts{scheme: scheme, hash: hash}
+	}
+}
+
+// The signer options of an unknown scheme, kept so the error shows up where the scheme is used.
+type unknownSchemeOpts struct {
+	scheme SignerScheme
+	hash   crypto.Hash
+}
+
+func (opts *unknownSchemeOpts) HashFunc() crypto.Hash {
+	return opts.hash
+}
+
+func checkSignerScheme(opts crypto.SignerOpts) error {
+	if opts, ok := opts.(*unknownSchemeOpts); ok {
+		return fmt.Errorf("%w, unknown signer scheme %d", ErrUnsupportedOpts, int(opts.scheme))
+	}
+	return nil
+}
+
 type RSASigner struct {
 	privateKey *rsa.PrivateKey
 	opts       crypto.SignerOpts
@@ -109,10 +244,26 @@ func NewRSASigner(privateKey *rsa.PrivateKey, opts crypto.SignerOpts) *RSASigner
 }
 
 func (sig *RSASigner) Sign(data []byte) (sign []byte, err error) {
-	h := sig.opts.HashFunc().New()
-	h.Write(data)
-	digest := h.Sum(nil)
+	return sig.SignContext(context.Background(), data)
+}
 
+// Same as Sign, but stops hashing and returns ctx.Err() once ctx is done.
+func (sig *RSASigner) SignContext(ctx context.Context, data []byte) (sign []byte, err error) {
+	digest, err := hashContext(ctx, sig.opts, data)
+	if err != nil {
+		return nil, err
+	}
+	return sig.SignDigest(digest)
+}
+
+// Sign a digest computed elsewhere, the data is not hashed again.
+//		The digest size must match the hash of opts.
+//		With crypto.Hash(0) the digest is signed as is with PKCS1 v1.5, without DigestInfo,
+//		e.g. the 36 bytes MD5+SHA1 digest of legacy TLS, the same as crypto.MD5SHA1 .
+func (sig *RSASigner) SignDigest(digest []byte) (sign []byte, err error) {
+	if err := checkDigest(sig.opts, &sig.privateKey.PublicKey, digest); err != nil {
+		return nil, err
+	}
 	return sig.privateKey.Sign(rand.Reader, digest, sig.opts)
 }
 
@@ -129,16 +280,87 @@ func NewRSAVerifier(publicKey *rsa.PublicKey, opts crypto.SignerOpts) *RSAVerifi
 }
 
 func (ver *RSAVerifier) Verify(data []byte, sign []byte) (err error) {
-	h := ver.opts.HashFunc().New()
-	h.Write(data)
-	digest := h.Sum(nil)
+	return ver.VerifyContext(context.Background(), data, sign)
+}
+
+// Same as Verify, but stops hashing and returns ctx.Err() once ctx is done.
+func (ver *RSAVerifier) VerifyContext(ctx context.Context, data []byte, sign []byte) (err error) {
+	digest, err := hashContext(ctx, ver.opts, data)
+	if err != nil {
+		return err
+	}
+	return ver.VerifyDigest(digest, sign)
+}
 
+// Verify the sign of a digest computed elsewhere, @see RSASigner.SignDigest .
+func (ver *RSAVerifier) VerifyDigest(digest []byte, sign []byte) (err error) {
+	if err := checkDigest(ver.opts, ver.publicKey, digest); err != nil {
+		return err
+	}
 	if pssOpts, ok := ver.opts.(*rsa.PSSOptions); ok {
 		return rsa.VerifyPSS(ver.publicKey, pssOpts.Hash, digest, sign, pssOpts)
 	}
 	return rsa.VerifyPKCS1v15(ver.publicKey, ver.opts.HashFunc(), digest, sign)
 }
 
+// Check the digest size against the hash of opts, a raw digest of crypto.Hash(0) is limited by the key size.
+func checkDigest(opts crypto.SignerOpts, key *rsa.PublicKey, digest []byte) error {
+	if err := checkSignerScheme(opts); err != nil {
+		return err
+	}
+	hash := opts.HashFunc()
+	if hash != 0 {
+		if len(digest) != hash.Size() {
+			return fmt.Errorf("%w %d, %v digest size is %d", ErrDigestSize, len(digest), hash, hash.Size())
+		}
+		return nil
+	}
+	if _, ok := opts.(*rsa.PSSOptions); ok {
+		return fmt.Errorf("%w, PSS requires a hash", ErrUnsupportedOpts)
+	}
+	if limit := key.Size() - 11; len(digest) > limit {
+		return fmt.Errorf("%w %d, larger than %d for the key", ErrDigestSize, len(digest), limit)
+	}
+	return nil
+}
+
+// The size of the blocks hashContext writes between checks of ctx.
+const hashBlockSize = 64 * 1024
+
+// Create the hash of opts to hash the data to sign.
+//		crypto.Hash(0) signs a digest as is, so there is no hash of data for it, @see RSASigner.SignDigest .
+func newSignerHash(opts crypto.SignerOpts) (hash.Hash, error) {
+	if err := checkSignerScheme(opts); err != nil {
+		return nil, err
+	}
+	h := opts.HashFunc()
+	if h == 0 {
+		return nil, fmt.Errorf("%w, crypto.Hash(0) can only sign a digest, use SignDigest", ErrUnsupportedOpts)
+	}
+	if !h.Available() {
+		return nil, fmt.Errorf("%w, hash %v is not available", ErrUnsupportedOpts, h)
+	}
+	return h.New(), nil
+}
+
+// Hash data block by block, returns ctx.Err() once ctx is done.
+func hashContext(ctx context.Context, opts crypto.SignerOpts, data []byte) ([]byte, error) {
+	h, err := newSignerHash(opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, block := range split(data, hashBlockSize) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		h.Write(block)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
 func split(buffer []byte, limit int) [][]byte {
 	var chunk []byte
 	chunks := make([][]byte, 0, len(buffer)/limit+1)
@@ -151,3 +373,62 @@ func split(buffer []byte, limit int) [][]byte {
 	}
 	return chunks
 }
+
+// Apply fn to every chunk with up to workers goroutines, the results keep the order of chunks.
+//		On failure the error of the first failed chunk is returned, and the remaining chunks are skipped.
+//		ctx is checked before every chunk, ctx.Err() is returned once it is done.
+func processChunks(ctx context.Context, chunks [][]byte, workers int, fn func(i int, chunk []byte) ([]byte, error)) ([][]byte, error) {
+	results := make([][]byte, len(chunks))
+	if workers <= 1 || len(chunks) <= 1 {
+		for i, chunk := range chunks {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			result, err := fn(i, chunk)
+			if err != nil {
+				return nil, err
+			}
+			results[i] = result
+		}
+		return results, nil
+	}
+
+	if workers > len(chunks) {
+		workers = len(chunks)
+	}
+	errs := make([]error, len(chunks))
+	var next atomic.Int64
+	var failed atomic.Bool
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for !failed.Load() {
+				if ctx.Err() != nil {
+					failed.Store(true)
+					return
+				}
+				i := int(next.Add(1) - 1)
+				if i >= len(chunks) {
+					return
+				}
+				results[i], errs[i] = fn(i, chunks[i])
+				if errs[i] != nil {
+					failed.Store(true)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	if failed.Load() {
+		return nil, ctx.Err()
+	}
+	return results, nil
+}
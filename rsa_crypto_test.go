@@ -1,9 +1,16 @@
 package rsacrypto
 
 import (
+	"context"
 	"crypto"
+	"crypto/md5"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
 	"encoding/base64"
 	"github.com/stretchr/testify/assert"
+	"runtime"
+	"strings"
 	"testing"
 )
 
@@ -210,3 +217,292 @@ V4jtLN1KSC8BhLRslQIDAQAB`
 	err = verifier.Verify([]byte(msg), signBytes)
 	assert.Nil(t, err)
 }
+
+func TestRSASigner_SignDigest(t *testing.T) {
+	msg := []byte(`A short message`)
+	digest := sha256.Sum256(msg)
+
+	for _, opts := range []crypto.SignerOpts{crypto.SHA256, &rsa.PSSOptions{Hash: crypto.SHA256}} {
+		for _, key := range testKeys {
+			privKey, err := NewRSAPrivateKey().SetEncodedKey(key.PrivateKey, nil)
+			assert.Nil(t, err)
+			pubKey, err := NewRSAPublicKey().SetEncodedKey(key.PublicKey, nil)
+			assert.Nil(t, err)
+			privKey.SetSignerOpts(opts)
+			pubKey.SetSignerOpts(opts)
+
+			// Compatible with Sign and Verify of the data.
+			sign, err := privKey.SignDigest(digest[:])
+			assert.Nil(t, err)
+			assert.Nil(t, pubKey.Verify(msg, sign))
+			sign, err = privKey.Sign(msg)
+			assert.Nil(t, err)
+			assert.Nil(t, pubKey.VerifyDigest(digest[:], sign))
+			assert.ErrorIs(t, pubKey.VerifyDigest(digest[:], sign[1:]), rsa.ErrVerification)
+
+			_, err = privKey.SignDigest(digest[:20])
+			assert.ErrorIs(t, err, ErrDigestSize)
+			assert.ErrorIs(t, pubKey.VerifyDigest(digest[:20], sign), ErrDigestSize)
+		}
+	}
+
+	// A raw MD5+SHA1 digest as signed by legacy TLS, crypto.Hash(0) is the same as crypto.MD5SHA1 .
+	priv, err := ParseEncodedPrivateKey(testKeys[1].PrivateKey, nil)
+	assert.Nil(t, err)
+	md5Sum, sha1Sum := md5.Sum(msg), sha1.Sum(msg)
+	md5sha1 := append(md5Sum[:], sha1Sum[:]...)
+	sign, err := NewRSASigner(priv, crypto.Hash(0)).SignDigest(md5sha1)
+	assert.Nil(t, err)
+	assert.Nil(t, NewRSAVerifier(&priv.PublicKey, crypto.MD5SHA1).VerifyDigest(md5sha1, sign))
+	assert.Nil(t, rsa.VerifyPKCS1v15(&priv.PublicKey, crypto.MD5SHA1, md5sha1, sign))
+	hash, recovered, err := NewRSAVerifier(&priv.PublicKey, nil).Recover(sign)
+	assert.Nil(t, err)
+	assert.Equal(t, crypto.Hash(0), hash)
+	assert.Equal(t, md5sha1, recovered)
+
+	// There is no hash of data for crypto.Hash(0), only digests could be signed.
+	privKey := NewRSAPrivateKey().SetKey(priv).SetSignerHash(crypto.Hash(0))
+	pubKey := NewRSAPublicKey().SetKey(&priv.PublicKey).SetSignerHash(crypto.Hash(0))
+	_, err = privKey.Sign(msg)
+	assert.ErrorIs(t, err, ErrUnsupportedOpts)
+	_, err = privKey.SignContext(context.Background(), msg)
+	assert.ErrorIs(t, err, ErrUnsupportedOpts)
+	assert.ErrorIs(t, pubKey.Verify(msg, sign), ErrUnsupportedOpts)
+	assert.ErrorIs(t, pubKey.VerifyContext(context.Background(), msg, sign), ErrUnsupportedOpts)
+	assert.Nil(t, pubKey.VerifyDigest(md5sha1, sign))
+
+	_, err = NewRSASigner(priv, crypto.Hash(0)).SignDigest(make([]byte, priv.Size()-10))
+	assert.ErrorIs(t, err, ErrDigestSize)
+	_, err = NewRSASigner(priv, &rsa.PSSOptions{}).SignDigest(md5sha1)
+	assert.ErrorIs(t, err, ErrUnsupportedOpts)
+	_, err = NewRSAPrivateKey().SignDigest(md5sha1)
+	assert.ErrorIs(t, err, ErrNoKey)
+	assert.ErrorIs(t, NewRSAPublicKey().SetKey(&priv.PublicKey).VerifyDigest(md5sha1, sign), ErrNoSignerOpts)
+}
+
+func TestRSAEncrypter_EncryptHybrid(t *testing.T) {
+	testData := []string{
+		``,
+		`A short message`,
+		strings.Repeat(`This is a very very long message. 这是一段很长很长的消息。`, 1000),
+	}
+
+	opts := &HybridOptions{Label: []byte("label")}
+	for _, plain := range testData {
+		for _, key := range testKeys {
+			pub, err := ParseEncodedPublicKey(key.PublicKey, nil)
+			assert.Nil(t, err)
+			priv, err := ParseEncodedPrivateKey(key.PrivateKey, nil)
+			assert.Nil(t, err)
+
+			cipher, err := NewRSAEncrypter(pub, opts).Encrypt([]byte(plain))
+			assert.Nil(t, err)
+			assert.Equal(t, hybridHeaderSize+pub.Size()+12+len(plain)+16, len(cipher))
+
+			decrypted, err := NewRSADecrypter(priv, opts).Decrypt(cipher)
+			assert.Nil(t, err)
+			assert.Equal(t, plain, string(decrypted))
+
+			// Tampered payload.
+			cipher[len(cipher)-1] ^= 1
+			_, err = NewRSADecrypter(priv, opts).Decrypt(cipher)
+			assert.ErrorIs(t, err, rsa.ErrDecryption)
+
+			// Truncated.
+			_, err = NewRSADecrypter(priv, opts).Decrypt(cipher[:hybridHeaderSize+pub.Size()+12])
+			assert.ErrorIs(t, err, ErrChunkSize)
+
+			// Unknown version.
+			cipher[len(hybridMagic)] = 2
+			_, err = NewRSADecrypter(priv, opts).Decrypt(cipher)
+			assert.ErrorIs(t, err, ErrUnsupportedOpts)
+
+			// The format is detected by the header with nil options only.
+			cipher, err = NewRSAEncrypter(pub, &HybridOptions{}).Encrypt([]byte(plain))
+			assert.Nil(t, err)
+			decrypted, err = NewRSADecrypter(priv, nil).Decrypt(cipher)
+			assert.Nil(t, err)
+			assert.Equal(t, plain, string(decrypted))
+			for _, decOpts := range []DecrypterOpts{&rsa.OAEPOptions{Hash: crypto.SHA256, Label: []byte("label")}, &FramedOptions{}} {
+				_, err = NewRSADecrypter(priv, decOpts).Decrypt(cipher)
+				assert.NotNil(t, err)
+			}
+			_, err = NewRSADecrypter(priv, opts).Decrypt(cipher)
+			assert.ErrorContains(t, err, "hybrid ciphertext")
+
+			// Chunked ciphertext.
+			cipher, err = NewRSAEncrypter(pub, nil).Encrypt([]byte(plain))
+			assert.Nil(t, err)
+			_, err = NewRSADecrypter(priv, opts).Decrypt(cipher)
+			assert.NotNil(t, err)
+		}
+	}
+}
+
+func TestRSAEncrypter_EncryptFramed(t *testing.T) {
+	testData := []string{
+		``,
+		`A short message`,
+		strings.Repeat(`This is a very very long message. 这是一段很长很长的消息。`, 20),
+	}
+
+	opts := &FramedOptions{}
+	for _, plain := range testData {
+		for _, key := range testKeys {
+			pub, err := ParseEncodedPublicKey(key.PublicKey, nil)
+			assert.Nil(t, err)
+			priv, err := ParseEncodedPrivateKey(key.PrivateKey, nil)
+			assert.Nil(t, err)
+			size := pub.Size()
+
+			cipher, err := NewRSAEncrypter(pub, opts).Encrypt([]byte(plain))
+			assert.Nil(t, err)
+			decrypted, err := NewRSADecrypter(priv, opts).Decrypt(cipher)
+			assert.Nil(t, err)
+			assert.Equal(t, plain, string(decrypted))
+
+			var frameErr *FrameError
+
+			// Truncated.
+			_, err = NewRSADecrypter(priv, opts).Decrypt(cipher[:len(cipher)-size])
+			assert.ErrorAs(t, err, &frameErr)
+			_, err = NewRSADecrypter(priv, opts).Decrypt(nil)
+			assert.ErrorAs(t, err, &frameErr)
+			assert.ErrorIs(t, err, ErrChunkSize)
+
+			// Duplicated.
+			duplicated := append(append([]byte{}, cipher...), cipher[:size]...)
+			_, err = NewRSADecrypter(priv, opts).Decrypt(duplicated)
+			assert.ErrorAs(t, err, &frameErr)
+
+			// Reordered.
+			if len(cipher) > size {
+				reordered := append(append([]byte{}, cipher[size:2*size]...), cipher[:size]...)
+				reordered = append(reordered, cipher[2*size:]...)
+				_, err = NewRSADecrypter(priv, opts).Decrypt(reordered)
+				assert.ErrorAs(t, err, &frameErr)
+				assert.Equal(t, 0, frameErr.Index)
+			}
+		}
+	}
+
+	// OAEP with SHA512 needs more than a 1024 bits key.
+	pub, err := ParseEncodedPublicKey(testKeys[1].PublicKey, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 128, pub.Size())
+	_, err = NewRSAEncrypter(pub, &FramedOptions{Hash: crypto.SHA512}).Encrypt([]byte(`A short message`))
+	assert.ErrorIs(t, err, ErrChunkSize)
+
+	// A hash which is not linked in.
+	priv, err := ParseEncodedPrivateKey(testKeys[1].PrivateKey, nil)
+	assert.Nil(t, err)
+	_, err = NewRSAEncrypter(pub, &FramedOptions{Hash: crypto.MD4}).Encrypt([]byte(`A short message`))
+	assert.ErrorIs(t, err, ErrUnsupportedOpts)
+	_, err = NewRSADecrypter(priv, &FramedOptions{Hash: crypto.MD4}).Decrypt(make([]byte, priv.Size()))
+	assert.ErrorIs(t, err, ErrUnsupportedOpts)
+}
+
+func TestRSAEncrypter_WithWorkers(t *testing.T) {
+	plain := strings.Repeat(`This is a very very long message. 这是一段很长很长的消息。`, 200)
+
+	for _, opts := range []EncrypterOpts{nil, &FramedOptions{}} {
+		for _, key := range testKeys {
+			pub, err := ParseEncodedPublicKey(key.PublicKey, nil)
+			assert.Nil(t, err)
+			priv, err := ParseEncodedPrivateKey(key.PrivateKey, nil)
+			assert.Nil(t, err)
+
+			cipher, err := NewRSAEncrypter(pub, opts).WithWorkers(4).Encrypt([]byte(plain))
+			assert.Nil(t, err)
+
+			// Sequential and parallel ciphers are compatible.
+			decrypted, err := NewRSADecrypter(priv, opts).Decrypt(cipher)
+			assert.Nil(t, err)
+			assert.Equal(t, plain, string(decrypted))
+			decrypted, err = NewRSADecrypter(priv, opts).WithWorkers(4).Decrypt(cipher)
+			assert.Nil(t, err)
+			assert.Equal(t, plain, string(decrypted))
+
+			cipher[len(cipher)/2] ^= 1
+			_, err = NewRSADecrypter(priv, opts).WithWorkers(4).Decrypt(cipher)
+			assert.NotNil(t, err)
+		}
+	}
+}
+
+func benchmarkRSADecrypter_Decrypt(b *testing.B, workers int) {
+	pub, err := ParseEncodedPublicKey(testKeys[0].PublicKey, nil)
+	assert.Nil(b, err)
+	priv, err := ParseEncodedPrivateKey(testKeys[0].PrivateKey, nil)
+	assert.Nil(b, err)
+	cipher, err := NewRSAEncrypter(pub, nil).Encrypt(make([]byte, 64*1024))
+	assert.Nil(b, err)
+
+	dec := NewRSADecrypter(priv, nil).WithWorkers(workers)
+	b.SetBytes(int64(len(cipher)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := dec.Decrypt(cipher)
+		assert.Nil(b, err)
+	}
+}
+
+func BenchmarkRSADecrypter_Decrypt(b *testing.B) {
+	benchmarkRSADecrypter_Decrypt(b, 1)
+}
+
+func BenchmarkRSADecrypter_DecryptParallel(b *testing.B) {
+	benchmarkRSADecrypter_Decrypt(b, runtime.NumCPU())
+}
+
+func benchmarkRSAEncrypter_Encrypt(b *testing.B, workers int) {
+	pub, err := ParseEncodedPublicKey(testKeys[0].PublicKey, nil)
+	assert.Nil(b, err)
+	plain := make([]byte, 64*1024)
+
+	enc := NewRSAEncrypter(pub, nil).WithWorkers(workers)
+	b.SetBytes(int64(len(plain)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := enc.Encrypt(plain)
+		assert.Nil(b, err)
+	}
+}
+
+func BenchmarkRSAEncrypter_Encrypt(b *testing.B) {
+	benchmarkRSAEncrypter_Encrypt(b, 1)
+}
+
+func BenchmarkRSAEncrypter_EncryptParallel(b *testing.B) {
+	benchmarkRSAEncrypter_Encrypt(b, runtime.NumCPU())
+}
+
+func TestRSADecrypter_DecryptContext(t *testing.T) {
+	plain := strings.Repeat(`This is a very very long message. 这是一段很长很长的消息。`, 20)
+	pub, err := ParseEncodedPublicKey(testKeys[0].PublicKey, nil)
+	assert.Nil(t, err)
+	priv, err := ParseEncodedPrivateKey(testKeys[0].PrivateKey, nil)
+	assert.Nil(t, err)
+
+	cipher, err := NewRSAEncrypter(pub, nil).EncryptContext(context.Background(), []byte(plain))
+	assert.Nil(t, err)
+	decrypted, err := NewRSADecrypter(priv, nil).DecryptContext(context.Background(), cipher)
+	assert.Nil(t, err)
+	assert.Equal(t, plain, string(decrypted))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, workers := range []int{1, 4} {
+		_, err = NewRSAEncrypter(pub, nil).WithWorkers(workers).EncryptContext(ctx, []byte(plain))
+		assert.ErrorIs(t, err, context.Canceled)
+		_, err = NewRSADecrypter(priv, nil).WithWorkers(workers).DecryptContext(ctx, cipher)
+		assert.ErrorIs(t, err, context.Canceled)
+		_, err = NewRSADecrypter(priv, &FramedOptions{}).WithWorkers(workers).DecryptContext(ctx, cipher)
+		assert.ErrorIs(t, err, context.Canceled)
+	}
+
+	_, err = NewRSASigner(priv, &DefaultSignerOpts{Hash: crypto.SHA256}).SignContext(ctx, []byte(plain))
+	assert.ErrorIs(t, err, context.Canceled)
+	err = NewRSAVerifier(pub, &DefaultSignerOpts{Hash: crypto.SHA256}).VerifyContext(ctx, []byte(plain), nil)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,66 @@
+package rsacrypto
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"golang.org/x/crypto/ssh"
+)
+
+// The fingerprint of key, the hash of its PKIX (SubjectPublicKeyInfo) DER data.
+//		With crypto.SHA256 it is the value used by certificate and key pinning,
+//		e.g. openssl pkey -pubin -outform DER | openssl dgst -sha256 .
+func Fingerprint(key *rsa.PublicKey, hash crypto.Hash) (fingerprint []byte, err error) {
+	if !hash.Available() {
+		return nil, fmt.Errorf("%w, hash %v is not available", ErrUnsupportedOpts, hash)
+	}
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+	h := hash.New()
+	h.Write(der)
+	return h.Sum(nil), nil
+}
+
+// The OpenSSH fingerprint of key, e.g. "SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8",
+// the same as ssh-keygen -l .
+func SSHFingerprint(key *rsa.PublicKey) (fingerprint string, err error) {
+	sshKey, err := ssh.NewPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+	return ssh.FingerprintSHA256(sshKey), nil
+}
+
+// The legacy OpenSSH MD5 fingerprint of key, e.g. "c1:b1:30:29:d7:b8:de:6c:97:77:10:d7:46:41:63:87",
+// the same as ssh-keygen -l -E md5 .
+func SSHFingerprintMD5(key *rsa.PublicKey) (fingerprint string, err error) {
+	sshKey, err := ssh.NewPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+	return ssh.FingerprintLegacyMD5(sshKey), nil
+}
+
+// A short stable identifier of key for logs and error messages,
+// the base64url SHA-256 JWK thumbprint, @see JWKThumbprint .
+//		It is also a good JWK kid.
+func KeyID(key *rsa.PublicKey) string {
+	thumbprint, err := JWKThumbprint(key, crypto.SHA256)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(thumbprint)
+}
+
+// Add the KeyID of key to the error of a key operation, so logs tell which key failed.
+//		The error is wrapped, so errors.Is and errors.As still match it.
+func withKeyID(err error, key *rsa.PublicKey) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%w, key %s", err, KeyID(key))
+}
@@ -0,0 +1,65 @@
+package rsacrypto
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRSAPublicKey_Fingerprint(t *testing.T) {
+	pubKey, err := NewRSAPublicKey().SetEncodedKey(testKeys[1].PublicKey, nil)
+	assert.Nil(t, err)
+
+	// Expected values are from openssl dgst -sha256 and ssh-keygen -l .
+	fingerprint, err := pubKey.Fingerprint(crypto.SHA256)
+	assert.Nil(t, err)
+	assert.Equal(t, "f29d5f9757fda926261834cd15183f89d3f4ecf817c758b222c923134a2516d9", hex.EncodeToString(fingerprint))
+	sshFingerprint, err := pubKey.SSHFingerprint()
+	assert.Nil(t, err)
+	assert.Equal(t, "SHA256:pR+Mq/u+GhHZSz64S+xFFTrJCEyJRkoVccOsso4CWpU", sshFingerprint)
+	sshFingerprint, err = pubKey.SSHFingerprintMD5()
+	assert.Nil(t, err)
+	assert.Equal(t, "c8:16:be:29:c9:1c:c7:68:44:37:ba:0c:aa:2d:86:74", sshFingerprint)
+	assert.Equal(t, "B3B5J_nfln25XmcCTiJKXAXXuPkIGST7abyRM9QxvyU", pubKey.KeyID())
+
+	// The private key has the same identity.
+	privKey, err := NewRSAPrivateKey().SetEncodedKey(testKeys[1].PrivateKey, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, pubKey.KeyID(), privKey.KeyID())
+	privFingerprint, err := privKey.Fingerprint(crypto.SHA256)
+	assert.Nil(t, err)
+	assert.Equal(t, fingerprint, privFingerprint)
+
+	_, err = pubKey.Fingerprint(crypto.Hash(0))
+	assert.ErrorIs(t, err, ErrUnsupportedOpts)
+	_, err = NewRSAPublicKey().Fingerprint(crypto.SHA256)
+	assert.ErrorIs(t, err, ErrNoKey)
+	_, err = NewRSAPublicKey().SSHFingerprint()
+	assert.ErrorIs(t, err, ErrNoKey)
+	assert.Empty(t, NewRSAPublicKey().KeyID())
+	assert.Empty(t, NewRSAPrivateKey().KeyID())
+}
+
+func TestRSAPrivateKey_KeyIDInErrors(t *testing.T) {
+	pubKey, err := NewRSAPublicKey().SetEncodedKey(testKeys[1].PublicKey, nil)
+	assert.Nil(t, err)
+	privKey, err := NewRSAPrivateKey().SetEncodedKey(testKeys[1].PrivateKey, nil)
+	assert.Nil(t, err)
+
+	_, err = privKey.Sign([]byte(`A short message`))
+	assert.ErrorIs(t, err, ErrNoSignerOpts)
+	assert.ErrorContains(t, err, privKey.KeyID())
+	err = pubKey.Verify([]byte(`A short message`), []byte(`not a signature`))
+	assert.ErrorIs(t, err, ErrNoSignerOpts)
+	assert.ErrorContains(t, err, pubKey.KeyID())
+
+	pubKey.SetSignerHash(crypto.SHA256)
+	err = pubKey.Verify([]byte(`A short message`), make([]byte, 128))
+	assert.ErrorIs(t, err, rsa.ErrVerification)
+	assert.ErrorContains(t, err, pubKey.KeyID())
+	_, err = privKey.Decrypt([]byte(`not a cipher`))
+	assert.NotNil(t, err)
+	assert.ErrorContains(t, err, privKey.KeyID())
+}
@@ -0,0 +1,104 @@
+package rsacrypto
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/binary"
+	"fmt"
+)
+
+// Options of the framed mode, used as both EncrypterOpts and DecrypterOpts.
+//		Like the chunked OAEP mode, but every chunk is encrypted with a label binding
+//		its index and the total chunk count, so dropped, duplicated, reordered or
+//		truncated chunks fail to decrypt with a *FrameError.
+type FramedOptions struct {
+	Hash  crypto.Hash // The OAEP hash, SHA256 if zero.
+	Label []byte      // The optional OAEP label, the frame info is appended to it.
+}
+
+func (opts *FramedOptions) hash() crypto.Hash {
+	if opts.Hash == 0 {
+		return crypto.SHA256
+	}
+	return opts.Hash
+}
+
+// The OAEP label of the chunk at index.
+func (opts *FramedOptions) label(index int, total int) []byte {
+	label := make([]byte, len(opts.Label), len(opts.Label)+16)
+	copy(label, opts.Label)
+	label = binary.BigEndian.AppendUint64(label, uint64(index))
+	return binary.BigEndian.AppendUint64(label, uint64(total))
+}
+
+// Returned when decrypting a framed cipher which has been tampered with.
+type FrameError struct {
+	Index int   // The index of the first bad chunk.
+	Total int   // The chunk count of the cipher.
+	Err   error // The underlying error.
+}
+
+func (e *FrameError) Error() string {
+	return fmt.Sprintf("rsacrypto: chunk %d of %d is invalid: %v", e.Index, e.Total, e.Err)
+}
+
+func (e *FrameError) Unwrap() error {
+	return e.Err
+}
+
+func encryptFramed(ctx context.Context, publicKey *rsa.PublicKey, plain []byte, opts *FramedOptions, workers int) ([]byte, error) {
+	hash := opts.hash()
+	if !hash.Available() {
+		return nil, fmt.Errorf("%w, hash %v is not available", ErrUnsupportedOpts, hash)
+	}
+	limit := publicKey.Size() - hash.Size()*2 - 2
+	if limit <= 0 {
+		return nil, fmt.Errorf("%w %d, the key is too small for the options", ErrChunkSize, limit)
+	}
+	chunks := split(plain, limit)
+	if len(chunks) == 0 {
+		// Always emit one chunk, so an empty cipher can not pass for an empty message.
+		chunks = append(chunks, plain)
+	}
+
+	total := len(chunks)
+	encryptedChunks, err := processChunks(ctx, chunks, workers, func(i int, chunk []byte) ([]byte, error) {
+		return rsa.EncryptOAEP(hash.New(), rand.Reader, publicKey, chunk, opts.label(i, total))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bytes.Join(encryptedChunks, nil), nil
+}
+
+func decryptFramed(ctx context.Context, privateKey *rsa.PrivateKey, cipher []byte, opts *FramedOptions, workers int) ([]byte, error) {
+	hash := opts.hash()
+	if !hash.Available() {
+		return nil, fmt.Errorf("%w, hash %v is not available", ErrUnsupportedOpts, hash)
+	}
+	limit := privateKey.Size()
+	chunks := split(cipher, limit)
+	total := len(chunks)
+	if total == 0 {
+		return nil, &FrameError{Index: 0, Total: 0, Err: fmt.Errorf("%w, empty cipher", ErrChunkSize)}
+	}
+
+	if len(chunks[total-1]) != limit {
+		return nil, &FrameError{Index: total - 1, Total: total, Err: ErrChunkSize}
+	}
+
+	decryptedChunks, err := processChunks(ctx, chunks, workers, func(i int, chunk []byte) ([]byte, error) {
+		decryptedChunk, err := rsa.DecryptOAEP(hash.New(), nil, privateKey, chunk, opts.label(i, total))
+		if err != nil {
+			return nil, &FrameError{Index: i, Total: total, Err: err}
+		}
+		return decryptedChunk, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bytes.Join(decryptedChunks, nil), nil
+}
@@ -0,0 +1,132 @@
+package rsacrypto
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"io"
+)
+
+// Options of the hybrid mode, used as both EncrypterOpts and DecrypterOpts.
+//		A random AES-256-GCM key encrypts the whole payload at once,
+//		and the AES key is wrapped with RSA-OAEP.
+//		It is much faster than chunked RSA for large data and authenticates the payload.
+//		Decrypt with nil options detects a hybrid ciphertext by its header, @see RSADecrypter.DecryptContext .
+type HybridOptions struct {
+	Hash  crypto.Hash // The OAEP hash, SHA256 if zero.
+	Label []byte      // The optional OAEP label.
+}
+
+func (opts *HybridOptions) hash() crypto.Hash {
+	if opts.Hash == 0 {
+		return crypto.SHA256
+	}
+	return opts.Hash
+}
+
+// Layout of a hybrid ciphertext:
+//		magic (4) | version (1) | OAEP hash (1) | wrapped key (RSA size) | GCM nonce (12) | sealed payload
+// Everything before the nonce is authenticated as GCM additional data.
+const (
+	hybridMagic      = "RSAH"
+	hybridVersion1   = 1
+	hybridHeaderSize = len(hybridMagic) + 2
+	hybridKeySize    = 32
+)
+
+func encryptHybrid(publicKey *rsa.PublicKey, plain []byte, opts *HybridOptions) ([]byte, error) {
+	hash := opts.hash()
+	if !hash.Available() {
+		return nil, fmt.Errorf("%w, hash %v is not available", ErrUnsupportedOpts, hash)
+	}
+
+	key := make([]byte, hybridKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+	wrappedKey, err := rsa.EncryptOAEP(hash.New(), rand.Reader, publicKey, key, opts.Label)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newHybridAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	buffer := bytes.NewBuffer(make([]byte, 0, hybridHeaderSize+len(wrappedKey)+len(nonce)+len(plain)+aead.Overhead()))
+	buffer.WriteString(hybridMagic)
+	buffer.WriteByte(hybridVersion1)
+	buffer.WriteByte(byte(hash))
+	buffer.Write(wrappedKey)
+	additionalData := buffer.Bytes()
+	buffer.Write(nonce)
+	return aead.Seal(buffer.Bytes(), nonce, plain, additionalData), nil
+}
+
+// Whether cipherData starts with the magic of a hybrid ciphertext.
+func hasHybridHeader(cipherData []byte) bool {
+	return len(cipherData) >= hybridHeaderSize && string(cipherData[:len(hybridMagic)]) == hybridMagic
+}
+
+// Decrypt a hybrid ciphertext, with nil opts the OAEP hash of the header is used without a label.
+func decryptHybrid(privateKey *rsa.PrivateKey, cipherData []byte, opts *HybridOptions) ([]byte, error) {
+	if !hasHybridHeader(cipherData) {
+		return nil, fmt.Errorf("%w, not a hybrid ciphertext", ErrUnsupportedOpts)
+	}
+	if version := cipherData[len(hybridMagic)]; version != hybridVersion1 {
+		return nil, fmt.Errorf("%w, hybrid ciphertext version %d", ErrUnsupportedOpts, version)
+	}
+	hash := crypto.Hash(cipherData[len(hybridMagic)+1])
+	var label []byte
+	if opts != nil {
+		if hash != opts.hash() {
+			return nil, fmt.Errorf("%w, hybrid ciphertext uses OAEP hash %v, expected %v", ErrUnsupportedOpts, hash, opts.hash())
+		}
+		label = opts.Label
+	} else if !hash.Available() {
+		return nil, fmt.Errorf("%w, hybrid ciphertext uses unavailable OAEP hash %d", ErrUnsupportedOpts, hash)
+	}
+
+	keyEnd := hybridHeaderSize + privateKey.Size()
+	if len(cipherData) < keyEnd {
+		return nil, fmt.Errorf("%w, hybrid ciphertext size %d is too short for the key", ErrChunkSize, len(cipherData))
+	}
+	key, err := rsa.DecryptOAEP(hash.New(), nil, privateKey, cipherData[hybridHeaderSize:keyEnd], label)
+	if err != nil {
+		return nil, fmt.Errorf("rsacrypto: hybrid ciphertext key: %w", err)
+	}
+	if len(key) != hybridKeySize {
+		return nil, fmt.Errorf("%w, invalid hybrid key size %d", ErrChunkSize, len(key))
+	}
+
+	aead, err := newHybridAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonceEnd := keyEnd + aead.NonceSize()
+	if len(cipherData) < nonceEnd+aead.Overhead() {
+		return nil, fmt.Errorf("%w, hybrid ciphertext size %d is too short for the payload", ErrChunkSize, len(cipherData))
+	}
+	plain, err := aead.Open(nil, cipherData[keyEnd:nonceEnd], cipherData[nonceEnd:], cipherData[:keyEnd])
+	if err != nil {
+		return nil, fmt.Errorf("%w, hybrid ciphertext payload: %w", rsa.ErrDecryption, err)
+	}
+	return plain, nil
+}
+
+func newHybridAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
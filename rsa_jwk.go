@@ -0,0 +1,271 @@
+package rsacrypto
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// A rsa JSON Web Key, @see https://tools.ietf.org/html/rfc7517 and https://tools.ietf.org/html/rfc7518#section-6.3 .
+//		Big integers are base64url encoded without padding.
+type JWK struct {
+	Kty string   `json:"kty"`
+	Kid string   `json:"kid,omitempty"`
+	Use string   `json:"use,omitempty"` // "sig" or "enc".
+	Alg string   `json:"alg,omitempty"` // e.g. "RS256", "PS256", "RSA-OAEP-256".
+	X5c []string `json:"x5c,omitempty"` // Standard base64 DER certificates, the first one holds the key.
+
+	// Public key parameters.
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// Private key parameters.
+	D  string `json:"d,omitempty"`
+	P  string `json:"p,omitempty"`
+	Q  string `json:"q,omitempty"`
+	DP string `json:"dp,omitempty"`
+	DQ string `json:"dq,omitempty"`
+	QI string `json:"qi,omitempty"`
+}
+
+// A JSON Web Key Set.
+type JWKS struct {
+	Keys []*JWK `json:"keys"`
+}
+
+// Optional members of a marshalled JWK.
+type JWKParams struct {
+	Kid          string
+	Use          string
+	Alg          string
+	Certificates []*x509.Certificate // Written to x5c, the first one must hold the key.
+}
+
+// Parse a JWK from JSON data, only rsa keys are accepted.
+func ParseJWK(data []byte) (jwk *JWK, err error) {
+	jwk = &JWK{}
+	if err := json.Unmarshal(data, jwk); err != nil {
+		return nil, fmt.Errorf("%w, invalid JWK: %v", ErrKeyFormat, err)
+	}
+	if jwk.Kty != "RSA" {
+		return nil, fmt.Errorf("%w, JWK key type is %q", ErrNotRSAKey, jwk.Kty)
+	}
+	return jwk, nil
+}
+
+// Parse a JWK Set from JSON data, and select the rsa key with the key ID kid.
+//		An empty kid selects the first rsa key.
+func ParseJWKS(data []byte, kid string) (jwk *JWK, err error) {
+	var set struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("%w, invalid JWKS: %v", ErrKeyFormat, err)
+	}
+
+	for _, raw := range set.Keys {
+		jwk, err := ParseJWK(raw)
+		if errors.Is(err, ErrNotRSAKey) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if kid == "" || jwk.Kid == kid {
+			return jwk, nil
+		}
+	}
+	return nil, fmt.Errorf("%w, no rsa key with kid %q in JWKS", ErrKeyFormat, kid)
+}
+
+// Create the JWK of a public key.
+func NewPublicJWK(key *rsa.PublicKey, params *JWKParams) (*JWK, error) {
+	jwk := &JWK{
+		Kty: "RSA",
+		N:   encodeJWKInt(key.N),
+		E:   encodeJWKInt(big.NewInt(int64(key.E))),
+	}
+	if params != nil {
+		jwk.Kid = params.Kid
+		jwk.Use = params.Use
+		jwk.Alg = params.Alg
+		for i, cert := range params.Certificates {
+			if i == 0 {
+				certKey, ok := cert.PublicKey.(*rsa.PublicKey)
+				if !ok || !certKey.Equal(key) {
+					return nil, fmt.Errorf("%w, the first certificate does not hold the key %s", ErrCertificate, KeyID(key))
+				}
+			}
+			jwk.X5c = append(jwk.X5c, base64.StdEncoding.EncodeToString(cert.Raw))
+		}
+	}
+	return jwk, nil
+}
+
+// Create the JWK of a private key, including the CRT parameters.
+func NewPrivateJWK(key *rsa.PrivateKey, params *JWKParams) (*JWK, error) {
+	if len(key.Primes) != 2 {
+		return nil, fmt.Errorf("%w, multi-prime keys are not supported by JWK", ErrKeyFormat)
+	}
+	jwk, err := NewPublicJWK(&key.PublicKey, params)
+	if err != nil {
+		return nil, err
+	}
+	dp, dq, qinv := crtValues(key)
+	jwk.D = encodeJWKInt(key.D)
+	jwk.P = encodeJWKInt(key.Primes[0])
+	jwk.Q = encodeJWKInt(key.Primes[1])
+	jwk.DP = encodeJWKInt(dp)
+	jwk.DQ = encodeJWKInt(dq)
+	jwk.QI = encodeJWKInt(qinv)
+	return jwk, nil
+}
+
+// The rsa public key of the JWK.
+//		If x5c is present, the first certificate must hold the same key,
+//		and it is used when n and e are absent.
+func (jwk *JWK) PublicKey() (*rsa.PublicKey, error) {
+	var certKey *rsa.PublicKey
+	if len(jwk.X5c) > 0 {
+		certs, err := jwk.Certificates()
+		if err != nil {
+			return nil, err
+		}
+		var ok bool
+		if certKey, ok = certs[0].PublicKey.(*rsa.PublicKey); !ok {
+			return nil, fmt.Errorf("%w, x5c certificate public key is %T", ErrNotRSAKey, certs[0].PublicKey)
+		}
+	}
+
+	if jwk.N == "" && jwk.E == "" && certKey != nil {
+		return certKey, nil
+	}
+	n, err := decodeJWKInt("n", jwk.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := decodeJWKInt("e", jwk.E)
+	if err != nil {
+		return nil, err
+	}
+	key, err := NewPublicKeyFromComponents(n.Bytes(), e.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if certKey != nil && !certKey.Equal(key) {
+		return nil, fmt.Errorf("%w, JWK key %s does not match the x5c certificate key %s", ErrKeyFormat, KeyID(key), KeyID(certKey))
+	}
+	return key, nil
+}
+
+// The rsa private key of the JWK, @see NewPrivateKeyFromComponents .
+//		d is required, p and q are recovered when both are absent.
+func (jwk *JWK) PrivateKey() (*rsa.PrivateKey, error) {
+	pub, err := jwk.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	if jwk.D == "" {
+		return nil, fmt.Errorf("%w, JWK is not a private key", ErrKeyFormat)
+	}
+
+	components := &PrivateKeyComponents{N: pub.N.Bytes(), E: big.NewInt(int64(pub.E)).Bytes()}
+	err = decodeComponentMembers([]componentMember{
+		{"d", jwk.D, &components.D},
+		{"p", jwk.P, &components.P},
+		{"q", jwk.Q, &components.Q},
+		{"dp", jwk.DP, &components.DP},
+		{"dq", jwk.DQ, &components.DQ},
+		{"qi", jwk.QI, &components.QInv},
+	}, decodeJWKInt)
+	if err != nil {
+		return nil, err
+	}
+	return NewPrivateKeyFromComponents(components)
+}
+
+// The certificates of x5c.
+func (jwk *JWK) Certificates() ([]*x509.Certificate, error) {
+	certs := make([]*x509.Certificate, 0, len(jwk.X5c))
+	for _, encoded := range jwk.X5c {
+		der, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("%w, invalid x5c: %v", ErrKeyFormat, err)
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	return certs, nil
+}
+
+// The JWK thumbprint, @see https://tools.ietf.org/html/rfc7638 .
+func (jwk *JWK) Thumbprint(hash crypto.Hash) ([]byte, error) {
+	key, err := jwk.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	return JWKThumbprint(key, hash)
+}
+
+// The JWK thumbprint of a rsa public key, @see https://tools.ietf.org/html/rfc7638 .
+//		Use base64.RawURLEncoding to get the usual string form.
+func JWKThumbprint(key *rsa.PublicKey, hash crypto.Hash) ([]byte, error) {
+	if !hash.Available() {
+		return nil, fmt.Errorf("%w, hash %v is not available", ErrUnsupportedOpts, hash)
+	}
+	// The required members in lexicographic order, without whitespace.
+	h := hash.New()
+	fmt.Fprintf(h, `{"e":"%s","kty":"RSA","n":"%s"}`, encodeJWKInt(big.NewInt(int64(key.E))), encodeJWKInt(key.N))
+	return h.Sum(nil), nil
+}
+
+// The signer and encrypter options of a JWA algorithm, @see https://tools.ietf.org/html/rfc7518 .
+func jwkAlgorithmOpts(alg string) (signerOpts crypto.SignerOpts, encrypterOpts EncrypterOpts, err error) {
+	switch alg {
+	case "":
+		return nil, nil, nil
+	case "RS256":
+		return &DefaultSignerOpts{Hash: crypto.SHA256}, nil, nil
+	case "RS384":
+		return &DefaultSignerOpts{Hash: crypto.SHA384}, nil, nil
+	case "RS512":
+		return &DefaultSignerOpts{Hash: crypto.SHA512}, nil, nil
+	case "PS256":
+		return NewSignerOpts(SchemePSS, crypto.SHA256), nil, nil
+	case "PS384":
+		return NewSignerOpts(SchemePSS, crypto.SHA384), nil, nil
+	case "PS512":
+		return NewSignerOpts(SchemePSS, crypto.SHA512), nil, nil
+	case "RSA1_5":
+		return nil, nil, nil
+	case "RSA-OAEP":
+		return nil, &rsa.OAEPOptions{Hash: crypto.SHA1}, nil
+	case "RSA-OAEP-256":
+		return nil, &rsa.OAEPOptions{Hash: crypto.SHA256}, nil
+	default:
+		return nil, nil, fmt.Errorf("%w, unsupported JWK alg %q", ErrUnsupportedOpts, alg)
+	}
+}
+
+func encodeJWKInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+func decodeJWKInt(name string, s string) (*big.Int, error) {
+	if s == "" {
+		return nil, fmt.Errorf("%w, JWK member %q is missing", ErrKeyFormat, name)
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w, invalid JWK member %q: %v", ErrKeyFormat, name, err)
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,182 @@
+package rsacrypto
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestRSAPrivateKey_MarshalJWK(t *testing.T) {
+	for _, key := range testKeys {
+		privKey, err := NewRSAPrivateKey().SetEncodedKey(key.PrivateKey, nil)
+		assert.Nil(t, err)
+
+		data, err := privKey.MarshalJWK(&JWKParams{Kid: "key-1", Use: "enc", Alg: "RSA-OAEP-256"})
+		assert.Nil(t, err)
+		jwk, err := ParseJWK(data)
+		assert.Nil(t, err)
+		assert.Equal(t, "key-1", jwk.Kid)
+		assert.Equal(t, "enc", jwk.Use)
+		assert.NotEmpty(t, jwk.DP)
+		assert.NotEmpty(t, jwk.QI)
+
+		// The alg sets the OAEP options of both wrappers.
+		jwkPrivKey, err := NewRSAPrivateKey().SetJWK(data)
+		assert.Nil(t, err)
+		assert.Equal(t, privKey.privateKey.D, jwkPrivKey.privateKey.D)
+
+		// The primes are recovered when only d is given.
+		jwk.P, jwk.Q, jwk.DP, jwk.DQ, jwk.QI = "", "", "", "", ""
+		recovered, err := jwk.PrivateKey()
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, privKey.privateKey.Primes, recovered.Primes)
+
+		// The key is not precomputed by marshalling, the CRT values still match it.
+		bare := &rsa.PrivateKey{PublicKey: privKey.privateKey.PublicKey, D: privKey.privateKey.D, Primes: privKey.privateKey.Primes}
+		jwk, err = NewPrivateJWK(bare, nil)
+		assert.Nil(t, err)
+		assert.Nil(t, bare.Precomputed.Dp)
+		_, err = jwk.PrivateKey()
+		assert.Nil(t, err)
+		pubData, err := NewRSAPublicKey().SetKey(&privKey.privateKey.PublicKey).MarshalJWK(&JWKParams{Alg: "RSA-OAEP-256"})
+		assert.Nil(t, err)
+		assert.NotContains(t, string(pubData), `"d"`)
+		pubKey, err := NewRSAPublicKey().SetJWK(pubData)
+		assert.Nil(t, err)
+
+		cipher, err := pubKey.Encrypt([]byte("A short message"))
+		assert.Nil(t, err)
+		plain, err := jwkPrivKey.Decrypt(cipher)
+		assert.Nil(t, err)
+		assert.Equal(t, "A short message", string(plain))
+	}
+
+	_, err := NewRSAPublicKey().MarshalJWK(nil)
+	assert.ErrorIs(t, err, ErrNoKey)
+	_, err = NewRSAPrivateKey().MarshalJWK(nil)
+	assert.ErrorIs(t, err, ErrNoKey)
+}
+
+func TestParseJWKS(t *testing.T) {
+	priv, err := ParseEncodedPrivateKey(testKeys[0].PrivateKey, nil)
+	assert.Nil(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+
+	sigJWK, err := NewPublicJWK(&priv.PublicKey, &JWKParams{Kid: "sig", Use: "sig", Alg: "PS256"})
+	assert.Nil(t, err)
+	encJWK, err := NewPublicJWK(&priv.PublicKey, &JWKParams{Kid: "enc", Use: "enc"})
+	assert.Nil(t, err)
+	data, err := json.Marshal(map[string]interface{}{
+		"keys": []interface{}{
+			map[string]string{"kty": "EC", "kid": "ec", "crv": "P-256",
+				"x": base64.RawURLEncoding.EncodeToString(ecKey.X.Bytes()),
+				"y": base64.RawURLEncoding.EncodeToString(ecKey.Y.Bytes())},
+			encJWK,
+			sigJWK,
+		},
+	})
+	assert.Nil(t, err)
+
+	jwk, err := ParseJWKS(data, "sig")
+	assert.Nil(t, err)
+	assert.Equal(t, "PS256", jwk.Alg)
+	jwk, err = ParseJWKS(data, "")
+	assert.Nil(t, err)
+	assert.Equal(t, "enc", jwk.Kid)
+	_, err = ParseJWKS(data, "ec")
+	assert.ErrorIs(t, err, ErrKeyFormat)
+
+	// The PS256 alg sets PSS signer options.
+	pubKey, err := NewRSAPublicKey().SetJWKS(data, "sig")
+	assert.Nil(t, err)
+	sign, err := NewRSAPrivateKey().SetKey(priv).
+		SetSignerOpts(&rsa.PSSOptions{Hash: crypto.SHA256, SaltLength: rsa.PSSSaltLengthEqualsHash}).
+		Sign([]byte("A short message"))
+	assert.Nil(t, err)
+	assert.Nil(t, pubKey.Verify([]byte("A short message"), sign))
+
+	_, err = ParseJWK([]byte(`{"kty":"EC","crv":"P-256"}`))
+	assert.ErrorIs(t, err, ErrNotRSAKey)
+	_, err = NewRSAPublicKey().SetJWK([]byte(`{"kty":"RSA","e":"AQAB"}`))
+	assert.ErrorIs(t, err, ErrKeyFormat)
+	for _, invalid := range []string{
+		`{"kty":"RSA","n":"AA","e":"AQAB"}`,
+		`{"kty":"RSA","n":"Dw","e":"AA"}`,
+		`{"kty":"RSA","n":"Dw","e":"AQ"}`,
+		`{"kty":"RSA","n":"Dw","e":"BA"}`,
+	} {
+		_, err = NewRSAPublicKey().SetJWK([]byte(invalid))
+		assert.ErrorIs(t, err, ErrKeyFormat)
+	}
+	_, err = NewRSAPrivateKey().SetJWK(data)
+	assert.NotNil(t, err)
+	_, err = NewRSAPublicKey().SetJWK([]byte(`{"kty":"RSA","alg":"HS256","n":"AQAB","e":"AQAB"}`))
+	assert.ErrorIs(t, err, ErrUnsupportedOpts)
+}
+
+func TestJWK_X5c(t *testing.T) {
+	priv, err := ParseEncodedPrivateKey(testKeys[0].PrivateKey, nil)
+	assert.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "rsacrypto test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &priv.PublicKey, priv)
+	assert.Nil(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.Nil(t, err)
+
+	jwk, err := NewPublicJWK(&priv.PublicKey, &JWKParams{Certificates: []*x509.Certificate{cert}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{base64.StdEncoding.EncodeToString(der)}, jwk.X5c)
+	pub, err := jwk.PublicKey()
+	assert.Nil(t, err)
+	assert.True(t, priv.PublicKey.Equal(pub))
+
+	// The key could come from x5c only.
+	jwk.N, jwk.E = "", ""
+	pub, err = jwk.PublicKey()
+	assert.Nil(t, err)
+	assert.True(t, priv.PublicKey.Equal(pub))
+
+	other, err := ParseEncodedPrivateKey(testKeys[1].PrivateKey, nil)
+	assert.Nil(t, err)
+	_, err = NewPublicJWK(&other.PublicKey, &JWKParams{Certificates: []*x509.Certificate{cert}})
+	assert.NotNil(t, err)
+	otherJWK, err := NewPublicJWK(&other.PublicKey, nil)
+	assert.Nil(t, err)
+	jwk.N, jwk.E = otherJWK.N, otherJWK.E
+	_, err = jwk.PublicKey()
+	assert.ErrorIs(t, err, ErrKeyFormat)
+}
+
+func TestJWKThumbprint(t *testing.T) {
+	der, err := base64.StdEncoding.DecodeString(testKeys[1].PublicKey)
+	assert.Nil(t, err)
+	pub, err := ParseDERPublicKey(der)
+	assert.Nil(t, err)
+
+	thumbprint, err := JWKThumbprint(pub, crypto.SHA256)
+	assert.Nil(t, err)
+	assert.Equal(t, "B3B5J_nfln25XmcCTiJKXAXXuPkIGST7abyRM9QxvyU", base64.RawURLEncoding.EncodeToString(thumbprint))
+
+	// Optional members do not change the thumbprint.
+	jwk, err := NewPublicJWK(pub, &JWKParams{Kid: "key-1", Use: "sig", Alg: "RS256"})
+	assert.Nil(t, err)
+	jwkThumbprint, err := jwk.Thumbprint(crypto.SHA256)
+	assert.Nil(t, err)
+	assert.Equal(t, thumbprint, jwkThumbprint)
+}
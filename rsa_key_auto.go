@@ -0,0 +1,246 @@
+package rsacrypto
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// The container a key was detected in, @see ParseAnyRSAKey .
+type KeyContainer int
+
+const (
+	ContainerUnknown KeyContainer = iota
+	ContainerDER                  // DER data, raw or text encoded, @see KeyInfo.Encoding .
+	ContainerPEM                  // PEM blocks, including "OPENSSH PRIVATE KEY".
+	ContainerOpenSSH              // An OpenSSH public key line.
+	ContainerJWK                  // A JSON Web Key.
+	ContainerJWKS                 // A JSON Web Key Set, the first rsa key is used.
+	ContainerXML                  // A .NET RSAKeyValue XML document.
+)
+
+func (c KeyContainer) String() string {
+	switch c {
+	case ContainerDER:
+		return "DER"
+	case ContainerPEM:
+		return "PEM"
+	case ContainerOpenSSH:
+		return "OpenSSH"
+	case ContainerJWK:
+		return "JWK"
+	case ContainerJWKS:
+		return "JWKS"
+	case ContainerXML:
+		return "XML"
+	default:
+		return "unknown"
+	}
+}
+
+// Describes a key detected by ParseAnyRSAKey .
+type KeyInfo struct {
+	Container KeyContainer
+	Encoding  string    // The text encoding of DER data, one of "hex", "base64", "base64 raw", "base64url", "base64url raw", or empty.
+	Format    KeyFormat // The format of DER data and PEM blocks, FormatUnknown for the other containers.
+	PEMType   string    // The PEM block type, only for ContainerPEM.
+	Private   bool
+}
+
+// e.g. "base64 DER PKCS8 private key", "PEM RSA PUBLIC KEY PKCS1 public key", "JWK private key".
+func (info *KeyInfo) String() string {
+	parts := make([]string, 0, 5)
+	if info.Encoding != "" {
+		parts = append(parts, info.Encoding)
+	}
+	parts = append(parts, info.Container.String())
+	if info.PEMType != "" {
+		parts = append(parts, info.PEMType)
+	}
+	if info.Format != FormatUnknown {
+		parts = append(parts, info.Format.String())
+	}
+	if info.Private {
+		parts = append(parts, "private key")
+	} else {
+		parts = append(parts, "public key")
+	}
+	return strings.Join(parts, " ")
+}
+
+// The text encodings of DER data tried by ParseAnyRSAKey, in order.
+var anyKeyEncodings = []struct {
+	name     string
+	encoding Encoding
+}{
+	{"hex", HexEncoding},
+	{"base64", base64.StdEncoding},
+	{"base64 raw", base64.RawStdEncoding},
+	{"base64url", base64.URLEncoding},
+	{"base64url raw", base64.RawURLEncoding},
+}
+
+var sshPublicKeyPattern = regexp.MustCompile(`(^|\s)(ssh-[a-z0-9-]+|ecdsa-sha2-[a-z0-9-]+|sk-[a-z0-9-]+@openssh\.com) AAAA`)
+
+// Parse a rsa key in any supported representation, the container is sniffed from the data:
+//		PEM (@see ParsePEMPrivateKey and ParsePEMPublicKey), OpenSSH public keys, JWK and JWKS JSON,
+//		.NET RSAKeyValue XML, and DER data, raw or encoded by hex or base64 (std/url, padded/unpadded).
+//		The key is either a *rsa.PublicKey or a *rsa.PrivateKey, @see KeyInfo.Private .
+//		PEM data holding a private key block returns the private key, even if a certificate comes first.
+//		Encrypted keys return an error matching ErrPassword.
+func ParseAnyRSAKey(data []byte) (key interface{}, info *KeyInfo, err error) {
+	// Raw DER is checked before trimming, as its last bytes may look like spaces.
+	if isDERSequence(data) {
+		key, format, err := parseAnyDERKey(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		return key, &KeyInfo{Container: ContainerDER, Format: format, Private: isPrivateKey(key)}, nil
+	}
+
+	text := bytes.TrimSpace(data)
+	switch {
+	case len(text) == 0:
+		return nil, nil, fmt.Errorf("%w, empty key data", ErrKeyFormat)
+	case bytes.Contains(text, []byte("-----BEGIN ")):
+		return parseAnyPEMKey(text)
+	case text[0] == '{':
+		return parseAnyJSONKey(text)
+	case text[0] == '<':
+		return parseAnyXMLKey(text)
+	case sshPublicKeyPattern.Match(text):
+		key, _, err := ParseSSHPublicKey(text)
+		if err != nil {
+			return nil, nil, err
+		}
+		return key, &KeyInfo{Container: ContainerOpenSSH, Format: FormatOpenSSH}, nil
+	}
+
+	// Text encoded DER, line breaks are allowed.
+	encoded := strings.Join(strings.Fields(string(text)), "")
+	for _, e := range anyKeyEncodings {
+		der, err := e.encoding.DecodeString(encoded)
+		if err != nil || !isDERSequence(der) {
+			continue
+		}
+		key, format, err := parseAnyDERKey(der)
+		if err != nil {
+			return nil, nil, err
+		}
+		return key, &KeyInfo{Container: ContainerDER, Encoding: e.name, Format: format, Private: isPrivateKey(key)}, nil
+	}
+	return nil, nil, fmt.Errorf("%w, could not detect the key container", ErrKeyFormat)
+}
+
+// Try the private key formats, then the public key formats, then certificates.
+func parseAnyDERKey(der []byte) (key interface{}, format KeyFormat, err error) {
+	errs := make([]*FormatError, 0, 5)
+	var parseErr *KeyParseError
+
+	priv, format, err := parseDERPrivateKey(der)
+	if err == nil {
+		return priv, format, nil
+	}
+	if !errors.As(err, &parseErr) {
+		return nil, FormatUnknown, err
+	}
+	errs = append(errs, parseErr.Errs...)
+
+	pub, format, err := parseDERPublicKey(der)
+	if err == nil {
+		return pub, format, nil
+	}
+	if !errors.As(err, &parseErr) {
+		return nil, FormatUnknown, err
+	}
+	errs = append(errs, parseErr.Errs...)
+
+	pub, err = parseCertificatePublicKey(der)
+	if err == nil {
+		return pub, FormatX509, nil
+	}
+	if errors.Is(err, ErrNotRSAKey) {
+		return nil, FormatUnknown, err
+	}
+	errs = append(errs, &FormatError{Format: FormatX509, Err: err})
+
+	return nil, FormatUnknown, &KeyParseError{Errs: errs}
+}
+
+func parseAnyPEMKey(data []byte) (key interface{}, info *KeyInfo, err error) {
+	var pemInfo *PEMInfo
+	if hasPEMPrivateKeyBlock(data) {
+		key, pemInfo, err = ParsePEMPrivateKey(data)
+	} else {
+		key, pemInfo, err = ParsePEMPublicKey(data)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, &KeyInfo{Container: ContainerPEM, Format: pemInfo.Format, PEMType: pemInfo.Type, Private: isPrivateKey(key)}, nil
+}
+
+func hasPEMPrivateKeyBlock(data []byte) bool {
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		switch block.Type {
+		case PEMTypePrivateKey, PEMTypeRSAPrivateKey, PEMTypeEncryptedPrivateKey, PEMTypeOpenSSHPrivateKey:
+			return true
+		}
+	}
+	return false
+}
+
+func parseAnyJSONKey(data []byte) (key interface{}, info *KeyInfo, err error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, nil, fmt.Errorf("%w, invalid JSON: %v", ErrKeyFormat, err)
+	}
+
+	var jwk *JWK
+	info = &KeyInfo{Container: ContainerJWK}
+	if _, ok := members["keys"]; ok {
+		jwk, err = ParseJWKS(data, "")
+		info.Container = ContainerJWKS
+	} else {
+		jwk, err = ParseJWK(data)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if jwk.D != "" {
+		key, err = jwk.PrivateKey()
+		info.Private = true
+	} else {
+		key, err = jwk.PublicKey()
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, info, nil
+}
+
+func parseAnyXMLKey(data []byte) (key interface{}, info *KeyInfo, err error) {
+	info = &KeyInfo{Container: ContainerXML}
+	if bytes.Contains(data, []byte("<D>")) {
+		key, err = ParseXMLPrivateKey(data)
+		info.Private = true
+	} else {
+		key, err = ParseXMLPublicKey(data)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, info, nil
+}
+
+func isPrivateKey(key interface{}) bool {
+	_, ok := key.(*rsa.PrivateKey)
+	return ok
+}
@@ -0,0 +1,97 @@
+package rsacrypto
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseAnyRSAKey(t *testing.T) {
+	privDer, err := base64.StdEncoding.DecodeString(testKeys[1].PrivateKey)
+	assert.Nil(t, err)
+	pubDer, err := base64.StdEncoding.DecodeString(testKeys[1].PublicKey)
+	assert.Nil(t, err)
+	priv, err := ParseDERPrivateKey(privDer)
+	assert.Nil(t, err)
+	privJWKData, err := NewRSAPrivateKey().SetKey(priv).MarshalJWK(nil)
+	assert.Nil(t, err)
+	pubJWKData, err := NewRSAPublicKey().SetKey(&priv.PublicKey).MarshalJWK(&JWKParams{Kid: "key-1"})
+	assert.Nil(t, err)
+	privXML, err := MarshalXMLPrivateKey(priv)
+	assert.Nil(t, err)
+
+	for _, test := range []struct {
+		data        []byte
+		description string
+	}{
+		{privDer, "DER PKCS1 private key"},
+		{pubDer, "DER PKIX public key"},
+		{[]byte(testKeys[1].PrivateKey), "base64 DER PKCS1 private key"},
+		{[]byte(base64.URLEncoding.EncodeToString(privDer)), "base64url DER PKCS1 private key"},
+		{[]byte(hex.EncodeToString(pubDer) + "\n"), "hex DER PKIX public key"},
+		{pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(priv)}), "PEM RSA PRIVATE KEY PKCS1 private key"},
+		{[]byte(testSSHPublicKey), "OpenSSH OpenSSH public key"},
+		{privJWKData, "JWK private key"},
+		{[]byte(`{"keys":[{"kty":"EC","crv":"P-256"},` + string(pubJWKData) + `]}`), "JWKS public key"},
+		{[]byte("\n  " + testXMLPublicKey + "\n"), "XML public key"},
+		{privXML, "XML private key"},
+	} {
+		key, info, err := ParseAnyRSAKey(test.data)
+		assert.Nil(t, err, test.description)
+		assert.Equal(t, test.description, info.String())
+		switch key := key.(type) {
+		case *rsa.PrivateKey:
+			assert.True(t, info.Private)
+			assert.Equal(t, priv.D, key.D)
+		case *rsa.PublicKey:
+			assert.False(t, info.Private)
+			assert.True(t, priv.PublicKey.Equal(key))
+		default:
+			t.Fatalf("unexpected key %T", key)
+		}
+	}
+
+	// Unpadded base64 is only distinguished when padding is needed.
+	der, err := base64.StdEncoding.DecodeString(testKeys[0].PrivateKey)
+	assert.Nil(t, err)
+	_, info, err := ParseAnyRSAKey([]byte(base64.RawURLEncoding.EncodeToString(der)))
+	assert.Nil(t, err)
+	assert.Equal(t, "base64url raw DER PKCS8 private key", info.String())
+
+	// Encrypted keys need a password.
+	_, info, err = ParseAnyRSAKey([]byte(testLegacyAES256Key))
+	assert.ErrorIs(t, err, ErrPassword)
+	assert.Nil(t, info)
+
+	for _, data := range []string{"", "not a key", "3082", `{"kty":"RSA"}`, "<RSAKeyValue/>"} {
+		_, _, err = ParseAnyRSAKey([]byte(data))
+		assert.ErrorIs(t, err, ErrKeyFormat, data)
+	}
+}
+
+func TestRSAPrivateKey_SetKeyAuto(t *testing.T) {
+	for _, key := range testKeys {
+		privKey, err := NewRSAPrivateKey().SetKeyAuto([]byte(key.PrivateKey))
+		assert.Nil(t, err)
+		pubKey, err := NewRSAPublicKey().SetKeyAuto([]byte(key.PublicKey))
+		assert.Nil(t, err)
+
+		cipher, err := pubKey.Encrypt([]byte("A short message"))
+		assert.Nil(t, err)
+		plain, err := privKey.Decrypt(cipher)
+		assert.Nil(t, err)
+		assert.Equal(t, "A short message", string(plain))
+
+		// The public key of a private key.
+		pubKey, err = NewRSAPublicKey().SetKeyAuto([]byte(key.PrivateKey))
+		assert.Nil(t, err)
+		assert.True(t, privKey.privateKey.PublicKey.Equal(pubKey.publicKey))
+
+		_, err = NewRSAPrivateKey().SetKeyAuto([]byte(key.PublicKey))
+		assert.ErrorIs(t, err, ErrKeyFormat)
+	}
+}
@@ -1,44 +1,83 @@
 package rsacrypto
 
 import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/base64"
-	"errors"
-	"strings"
+	"encoding/pem"
+	"fmt"
 )
 
+// The DER format a key is stored in.
+type KeyFormat int
+
+const (
+	FormatUnknown KeyFormat = iota
+	FormatPKCS1             // RSA specific, "RSA PUBLIC KEY" / "RSA PRIVATE KEY".
+	FormatPKCS8             // Generic private key, "PRIVATE KEY".
+	FormatPKIX              // Generic public key (SubjectPublicKeyInfo), "PUBLIC KEY".
+	FormatX509              // Public key embedded in a X.509 certificate, "CERTIFICATE".
+	FormatSEC1              // EC private key, "EC PRIVATE KEY". Only used by ParseAnyPrivateKey.
+	FormatOpenSSH           // OpenSSH key, "ssh-rsa AAAA..." / "OPENSSH PRIVATE KEY".
+)
+
+func (f KeyFormat) String() string {
+	switch f {
+	case FormatPKCS1:
+		return "PKCS1"
+	case FormatPKCS8:
+		return "PKCS8"
+	case FormatPKIX:
+		return "PKIX"
+	case FormatX509:
+		return "X509"
+	case FormatSEC1:
+		return "SEC1"
+	case FormatOpenSSH:
+		return "OpenSSH"
+	default:
+		return "unknown"
+	}
+}
+
 // Parse rsa public key from DER (binary) data.
 //		PKIX, PKCS1 formats would try one by one.
 //		About DER, @see https://en.wikipedia.org/wiki/X.690#DER_encoding .
 func ParseDERPublicKey(der []byte) (key *rsa.PublicKey, err error) {
+	key, _, err = parseDERPublicKey(der)
+	return key, err
+}
+
+// Same as ParseDERPublicKey, but also returns the detected format.
+func parseDERPublicKey(der []byte) (key *rsa.PublicKey, format KeyFormat, err error) {
 	// Try PKIX format.
 	key1, err1 := x509.ParsePKIXPublicKey(der)
 	if err1 == nil {
-		return key1.(*rsa.PublicKey), nil
+		rsaKey, ok := key1.(*rsa.PublicKey)
+		if !ok {
+			return nil, FormatPKIX, fmt.Errorf("%w, got a %s public key", ErrNotRSAKey, keyAlgorithm(key1))
+		}
+		return rsaKey, FormatPKIX, nil
 	}
 
 	// Log the first error.
-	errs := make([]error, 0, 2)
-	errs = append(errs, err1)
+	errs := make([]*FormatError, 0, 2)
+	errs = append(errs, &FormatError{Format: FormatPKIX, Err: err1})
 
 	// Try PKCS1 format.
 	key2, err2 := x509.ParsePKCS1PublicKey(der)
 	if err2 == nil {
-		return key2, nil
+		return key2, FormatPKCS1, nil
 	}
 
 	// Log the second error.
-	errs = append(errs, err2)
-
-	// Build error message.
-	b := strings.Builder{}
-	for _, e := range errs {
-		b.WriteString(e.Error())
-		b.WriteString("\n")
-	}
+	errs = append(errs, &FormatError{Format: FormatPKCS1, Err: err2})
 
-	return nil, errors.New(b.String())
+	return nil, FormatUnknown, &KeyParseError{Errs: errs}
 }
 
 // Parse rsa public key from a base64 string.
@@ -59,33 +98,36 @@ func ParseEncodedPublicKey(keyBase64 string, encoding Encoding) (key *rsa.Public
 //		PKCS8, PKCS1 formats would try one by one.
 //		About DER, @see https://en.wikipedia.org/wiki/X.690#DER_encoding .
 func ParseDERPrivateKey(der []byte) (key *rsa.PrivateKey, err error) {
+	key, _, err = parseDERPrivateKey(der)
+	return key, err
+}
+
+// Same as ParseDERPrivateKey, but also returns the detected format.
+func parseDERPrivateKey(der []byte) (key *rsa.PrivateKey, format KeyFormat, err error) {
 	// Try PKCS8 format.
 	key1, err1 := x509.ParsePKCS8PrivateKey(der)
 	if err1 == nil {
-		return key1.(*rsa.PrivateKey), nil
+		rsaKey, ok := key1.(*rsa.PrivateKey)
+		if !ok {
+			return nil, FormatPKCS8, fmt.Errorf("%w, got a %s private key", ErrNotRSAKey, keyAlgorithm(key1))
+		}
+		return rsaKey, FormatPKCS8, nil
 	}
 
 	// Log the first error.
-	errs := make([]error, 0, 2)
-	errs = append(errs, err1)
+	errs := make([]*FormatError, 0, 2)
+	errs = append(errs, &FormatError{Format: FormatPKCS8, Err: err1})
 
 	// Try PKCS1 format.
 	key2, err2 := x509.ParsePKCS1PrivateKey(der)
 	if err2 == nil {
-		return key2, nil
+		return key2, FormatPKCS1, nil
 	}
 
 	// Log the second error.
-	errs = append(errs, err2)
-
-	// Build error message.
-	b := strings.Builder{}
-	for _, e := range errs {
-		b.WriteString(e.Error())
-		b.WriteString("\n")
-	}
+	errs = append(errs, &FormatError{Format: FormatPKCS1, Err: err2})
 
-	return nil, errors.New(b.String())
+	return nil, FormatUnknown, &KeyParseError{Errs: errs}
 }
 
 // Parse rsa private key from a base64 string.
@@ -101,3 +143,130 @@ func ParseEncodedPrivateKey(keyBase64 string, encoding Encoding) (key *rsa.Priva
 
 	return ParseDERPrivateKey(der)
 }
+
+// Parse a public key of any algorithm supported by crypto/x509 from DER or PEM data.
+//		PKIX, PKCS1 formats would try one by one, a PEM block is decoded first.
+//		It is for key stores mixing rsa keys with ECDSA/Ed25519 keys.
+func ParseAnyPublicKey(data []byte) (key crypto.PublicKey, err error) {
+	if block, _ := pem.Decode(data); block != nil {
+		data = block.Bytes
+	}
+
+	key1, err1 := x509.ParsePKIXPublicKey(data)
+	if err1 == nil {
+		return key1, nil
+	}
+	key2, err2 := x509.ParsePKCS1PublicKey(data)
+	if err2 == nil {
+		return key2, nil
+	}
+
+	return nil, &KeyParseError{Errs: []*FormatError{
+		{Format: FormatPKIX, Err: err1},
+		{Format: FormatPKCS1, Err: err2},
+	}}
+}
+
+// Parse a private key of any algorithm supported by crypto/x509 from DER or PEM data.
+//		PKCS8, PKCS1, SEC1 (EC) formats would try one by one, a PEM block is decoded first.
+//		Keys which can not sign, e.g. X25519, are rejected.
+func ParseAnyPrivateKey(data []byte) (key crypto.Signer, err error) {
+	if block, _ := pem.Decode(data); block != nil {
+		data = block.Bytes
+	}
+
+	key1, err1 := x509.ParsePKCS8PrivateKey(data)
+	if err1 == nil {
+		signer, ok := key1.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("%w, a %s private key can not sign", ErrKeyFormat, keyAlgorithm(key1))
+		}
+		return signer, nil
+	}
+	key2, err2 := x509.ParsePKCS1PrivateKey(data)
+	if err2 == nil {
+		return key2, nil
+	}
+	key3, err3 := x509.ParseECPrivateKey(data)
+	if err3 == nil {
+		return key3, nil
+	}
+
+	return nil, &KeyParseError{Errs: []*FormatError{
+		{Format: FormatPKCS8, Err: err1},
+		{Format: FormatPKCS1, Err: err2},
+		{Format: FormatSEC1, Err: err3},
+	}}
+}
+
+// The algorithm name of a public or private key, used in error messages.
+func keyAlgorithm(key interface{}) string {
+	switch k := key.(type) {
+	case *rsa.PublicKey, *rsa.PrivateKey:
+		return "RSA"
+	case *ecdsa.PublicKey:
+		return "ECDSA " + k.Curve.Params().Name
+	case *ecdsa.PrivateKey:
+		return "ECDSA " + k.Curve.Params().Name
+	case ed25519.PublicKey, ed25519.PrivateKey:
+		return "Ed25519"
+	case *ecdh.PublicKey:
+		return fmt.Sprintf("ECDH %v", k.Curve())
+	case *ecdh.PrivateKey:
+		return fmt.Sprintf("ECDH %v", k.Curve())
+	default:
+		return fmt.Sprintf("%T", key)
+	}
+}
+
+// Marshal rsa public key to DER (binary) data in the PKIX or PKCS1 format.
+func MarshalDERPublicKey(key *rsa.PublicKey, format KeyFormat) (der []byte, err error) {
+	switch format {
+	case FormatPKIX:
+		return x509.MarshalPKIXPublicKey(key)
+	case FormatPKCS1:
+		return x509.MarshalPKCS1PublicKey(key), nil
+	default:
+		return nil, fmt.Errorf("%w %s for public key", ErrKeyFormat, format)
+	}
+}
+
+// Marshal rsa public key to a string with the given encoding.
+func MarshalEncodedPublicKey(key *rsa.PublicKey, format KeyFormat, encoding Encoding) (encodedKey string, err error) {
+	if encoding == nil {
+		encoding = base64.StdEncoding
+	}
+
+	der, err := MarshalDERPublicKey(key, format)
+	if err != nil {
+		return "", err
+	}
+
+	return encoding.EncodeToString(der), nil
+}
+
+// Marshal rsa private key to DER (binary) data in the PKCS8 or PKCS1 format.
+func MarshalDERPrivateKey(key *rsa.PrivateKey, format KeyFormat) (der []byte, err error) {
+	switch format {
+	case FormatPKCS8:
+		return x509.MarshalPKCS8PrivateKey(key)
+	case FormatPKCS1:
+		return x509.MarshalPKCS1PrivateKey(key), nil
+	default:
+		return nil, fmt.Errorf("%w %s for private key", ErrKeyFormat, format)
+	}
+}
+
+// Marshal rsa private key to a string with the given encoding.
+func MarshalEncodedPrivateKey(key *rsa.PrivateKey, format KeyFormat, encoding Encoding) (encodedKey string, err error) {
+	if encoding == nil {
+		encoding = base64.StdEncoding
+	}
+
+	der, err := MarshalDERPrivateKey(key, format)
+	if err != nil {
+		return "", err
+	}
+
+	return encoding.EncodeToString(der), nil
+}
@@ -1,6 +1,15 @@
 package rsacrypto
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -92,3 +101,71 @@ func TestParseBase64PrivateKey(t *testing.T) {
 		assert.Equal(t, pub.N, priv.N)
 	}
 }
+
+func TestParseDERPublicKey_Error(t *testing.T) {
+	_, err := ParseDERPublicKey([]byte("not a key"))
+	assert.ErrorIs(t, err, ErrKeyFormat)
+
+	var parseErr *KeyParseError
+	assert.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, 2, len(parseErr.Errs))
+	assert.Equal(t, FormatPKIX, parseErr.Errs[0].Format)
+	assert.Equal(t, FormatPKCS1, parseErr.Errs[1].Format)
+
+	var formatErr *FormatError
+	assert.ErrorAs(t, err, &formatErr)
+	assert.Equal(t, FormatPKIX, formatErr.Format)
+
+	_, err = ParseDERPrivateKey([]byte("not a key"))
+	assert.ErrorIs(t, err, ErrKeyFormat)
+	assert.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, FormatPKCS8, parseErr.Errs[0].Format)
+}
+
+func TestParseDERPublicKey_NotRSA(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+
+	for _, priv := range []crypto.Signer{ecKey, edKey} {
+		pubDer, err := x509.MarshalPKIXPublicKey(priv.Public())
+		assert.Nil(t, err)
+		privDer, err := x509.MarshalPKCS8PrivateKey(priv)
+		assert.Nil(t, err)
+
+		_, err = ParseDERPublicKey(pubDer)
+		assert.ErrorIs(t, err, ErrNotRSAKey)
+		_, err = ParseDERPrivateKey(privDer)
+		assert.ErrorIs(t, err, ErrNotRSAKey)
+		_, err = NewRSAPublicKey().SetEncodedKey(base64.StdEncoding.EncodeToString(pubDer), nil)
+		assert.ErrorIs(t, err, ErrNotRSAKey)
+
+		pub, err := ParseAnyPublicKey(pubDer)
+		assert.Nil(t, err)
+		assert.Equal(t, priv.Public(), pub)
+		signer, err := ParseAnyPrivateKey(privDer)
+		assert.Nil(t, err)
+		assert.Equal(t, priv.Public(), signer.Public())
+	}
+	ecDer, err := x509.MarshalPKIXPublicKey(ecKey.Public())
+	assert.Nil(t, err)
+	_, err = ParseDERPublicKey(ecDer)
+	assert.Contains(t, err.Error(), "ECDSA P-256")
+
+	// SEC1 EC private key in PEM.
+	sec1, err := x509.MarshalECPrivateKey(ecKey)
+	assert.Nil(t, err)
+	signer, err := ParseAnyPrivateKey(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1}))
+	assert.Nil(t, err)
+	assert.Equal(t, ecKey.Public(), signer.Public())
+
+	// RSA keys are supported too.
+	for _, key := range testKeys {
+		der, err := base64.StdEncoding.DecodeString(key.PrivateKey)
+		assert.Nil(t, err)
+		signer, err := ParseAnyPrivateKey(der)
+		assert.Nil(t, err)
+		assert.IsType(t, &rsa.PrivateKey{}, signer)
+	}
+}
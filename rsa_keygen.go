@@ -0,0 +1,163 @@
+package rsacrypto
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+)
+
+// The minimum modulus size accepted by GenerateKeyPair.
+const MinKeyBits = 2048
+
+// The public exponent used when KeyPairOpts.PublicExponent is not set.
+const DefaultPublicExponent = 65537
+
+// Options for GenerateKeyPair, a nil *KeyPairOpts means all defaults.
+//		Without Rand the keys are generated by crypto/rsa, suitable for production.
+//		With Rand they are generated by a simple variable-time generator of this package,
+//		only meant for deterministic test fixtures, never use such keys in production.
+type KeyPairOpts struct {
+	PublicExponent int       // An odd number >= 3, DefaultPublicExponent if zero. Others require Rand.
+	Rand           io.Reader // Source of randomness for deterministic test fixtures, crypto/rsa generates the keys if nil.
+
+	// Options copied to the returned keys.
+	EncrypterOpts EncrypterOpts     // PKCS1v15 if nil.
+	DecrypterOpts DecrypterOpts     // PKCS1v15 if nil.
+	SignerOpts    crypto.SignerOpts // PKCS1v15 with SHA256 if nil.
+}
+
+// Generate a rsa key pair with a modulus of the given bit size.
+//		bits must not be less than MinKeyBits.
+//		The returned keys are ready to encrypt/decrypt and sign/verify.
+func GenerateKeyPair(bits int, opts *KeyPairOpts) (*RSAPrivateKey, *RSAPublicKey, error) {
+	if opts == nil {
+		opts = &KeyPairOpts{}
+	}
+	if bits < MinKeyBits {
+		return nil, nil, fmt.Errorf("%w, key size %d is less than the minimum %d bits", ErrUnsupportedOpts, bits, MinKeyBits)
+	}
+
+	e := opts.PublicExponent
+	if e == 0 {
+		e = DefaultPublicExponent
+	}
+	if e < 3 || e%2 == 0 || e > math.MaxInt32 {
+		return nil, nil, fmt.Errorf("%w, invalid public exponent %d", ErrUnsupportedOpts, e)
+	}
+
+	var key *rsa.PrivateKey
+	var err error
+	if opts.Rand != nil {
+		// The official library always uses its own randomness and exponent,
+		// so fixtures need our own generation.
+		key, err = generateKey(opts.Rand, bits, e)
+	} else if e == DefaultPublicExponent {
+		key, err = rsa.GenerateKey(rand.Reader, bits)
+	} else {
+		return nil, nil, fmt.Errorf("%w, public exponent %d is only supported for test fixtures with KeyPairOpts.Rand", ErrUnsupportedOpts, e)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signerOpts := opts.SignerOpts
+	if signerOpts == nil {
+		signerOpts = &DefaultSignerOpts{Hash: crypto.SHA256}
+	}
+
+	privKey := NewRSAPrivateKey().
+		SetKey(key).
+		SetDecrypterOpts(opts.DecrypterOpts).
+		SetSignerOpts(signerOpts)
+	pubKey := NewRSAPublicKey().
+		SetKey(&key.PublicKey).
+		SetEncrypterOpts(opts.EncrypterOpts).
+		SetSignerOpts(signerOpts)
+	return privKey, pubKey, nil
+}
+
+// Generate a two-prime rsa key with the public exponent e, reading all randomness from random.
+//		The primality tests of math/big are not constant time, so it is only for test fixtures.
+func generateKey(random io.Reader, bits int, e int) (*rsa.PrivateKey, error) {
+	bigE := big.NewInt(int64(e))
+	one := big.NewInt(1)
+	for {
+		p, err := generatePrime(random, bits-bits/2, bigE)
+		if err != nil {
+			return nil, err
+		}
+		q, err := generatePrime(random, bits/2, bigE)
+		if err != nil {
+			return nil, err
+		}
+		if p.Cmp(q) == 0 {
+			continue
+		}
+
+		n := new(big.Int).Mul(p, q)
+		if n.BitLen() != bits {
+			continue
+		}
+
+		pMinus1 := new(big.Int).Sub(p, one)
+		qMinus1 := new(big.Int).Sub(q, one)
+		totient := new(big.Int).Mul(pMinus1, qMinus1)
+		d := new(big.Int).ModInverse(bigE, totient)
+		if d == nil {
+			continue
+		}
+
+		key := &rsa.PrivateKey{
+			PublicKey: rsa.PublicKey{N: n, E: e},
+			D:         d,
+			Primes:    []*big.Int{p, q},
+		}
+		key.Precompute()
+		if err := key.Validate(); err != nil {
+			return nil, err
+		}
+		return key, nil
+	}
+}
+
+// Generate a prime p of the given bit length whose p-1 is coprime to e.
+//		The top two bits are set so the product of two such primes has the full length.
+func generatePrime(random io.Reader, bits int, e *big.Int) (*big.Int, error) {
+	if bits < 16 {
+		return nil, fmt.Errorf("%w, prime size %d is too small", ErrUnsupportedOpts, bits)
+	}
+
+	b := make([]byte, (bits+7)/8)
+	extra := uint(len(b)*8 - bits)
+	p := new(big.Int)
+	pMinus1 := new(big.Int)
+	gcd := new(big.Int)
+	for {
+		if _, err := io.ReadFull(random, b); err != nil {
+			return nil, err
+		}
+
+		b[0] &= uint8(0xff >> extra)
+		if extra < 7 {
+			b[0] |= 0xc0 >> extra
+		} else {
+			b[0] |= 0x01
+			b[1] |= 0x80
+		}
+		b[len(b)-1] |= 1
+
+		p.SetBytes(b)
+		if !p.ProbablyPrime(20) {
+			continue
+		}
+		pMinus1.Sub(p, big.NewInt(1))
+		if gcd.GCD(nil, nil, pMinus1, e).Cmp(big.NewInt(1)) != 0 {
+			continue
+		}
+		return p, nil
+	}
+}
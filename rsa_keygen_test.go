@@ -0,0 +1,67 @@
+package rsacrypto
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"github.com/stretchr/testify/assert"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestGenerateKeyPair(t *testing.T) {
+	privKey, pubKey, err := GenerateKeyPair(2048, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 2048, privKey.privateKey.N.BitLen())
+	assert.Equal(t, DefaultPublicExponent, pubKey.publicKey.E)
+
+	plain := `A short message`
+	cipher, err := pubKey.Encrypt([]byte(plain))
+	assert.Nil(t, err)
+	decrypted, err := privKey.Decrypt(cipher)
+	assert.Nil(t, err)
+	assert.Equal(t, plain, string(decrypted))
+
+	// Signer options are pre-populated.
+	sign, err := privKey.Sign([]byte(plain))
+	assert.Nil(t, err)
+	assert.Nil(t, pubKey.Verify([]byte(plain), sign))
+
+	_, _, err = GenerateKeyPair(1024, nil)
+	assert.ErrorIs(t, err, ErrUnsupportedOpts)
+	_, _, err = GenerateKeyPair(2048, &KeyPairOpts{PublicExponent: 4})
+	assert.ErrorIs(t, err, ErrUnsupportedOpts)
+	// Custom exponents are only generated for test fixtures.
+	_, _, err = GenerateKeyPair(2048, &KeyPairOpts{PublicExponent: 3})
+	assert.ErrorIs(t, err, ErrUnsupportedOpts)
+}
+
+func TestGenerateKeyPair_Options(t *testing.T) {
+	opts := &KeyPairOpts{
+		PublicExponent: 3,
+		Rand:           rand.NewChaCha8([32]byte{1}),
+		EncrypterOpts:  &rsa.OAEPOptions{Hash: crypto.SHA256},
+		DecrypterOpts:  &rsa.OAEPOptions{Hash: crypto.SHA256},
+		SignerOpts:     &rsa.PSSOptions{Hash: crypto.SHA256},
+	}
+	privKey, pubKey, err := GenerateKeyPair(2048, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, pubKey.publicKey.E)
+	assert.Equal(t, 2048, pubKey.publicKey.N.BitLen())
+
+	plain := `A short message`
+	cipher, err := pubKey.Encrypt([]byte(plain))
+	assert.Nil(t, err)
+	decrypted, err := privKey.Decrypt(cipher)
+	assert.Nil(t, err)
+	assert.Equal(t, plain, string(decrypted))
+
+	sign, err := privKey.Sign([]byte(plain))
+	assert.Nil(t, err)
+	assert.Nil(t, pubKey.Verify([]byte(plain), sign))
+
+	// The same seed generates the same key.
+	opts.Rand = rand.NewChaCha8([32]byte{1})
+	privKey2, _, err := GenerateKeyPair(2048, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, privKey.privateKey.D, privKey2.privateKey.D)
+}
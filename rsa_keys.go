@@ -1,10 +1,13 @@
 package rsacrypto
 
 import (
+	"context"
 	"crypto"
 	"crypto/rsa"
+	"crypto/x509"
 	"encoding/json"
-	"errors"
+	"fmt"
+	"io"
 )
 
 type MarshalFunc func(v interface{}) ([]byte, error)
@@ -15,6 +18,7 @@ type RSAPublicKey struct {
 	encrypterOpts EncrypterOpts
 	marshalFunc   MarshalFunc // Used to encrypt an object, could be one of json.Marshal/xml.Marshal/yaml.Marshal .
 	signerOpts    crypto.SignerOpts
+	workers       int
 }
 
 func NewRSAPublicKey() *RSAPublicKey {
@@ -23,6 +27,7 @@ func NewRSAPublicKey() *RSAPublicKey {
 		encrypterOpts: nil,
 		marshalFunc:   json.Marshal,
 		signerOpts:    nil,
+		workers:       0,
 	}
 }
 
@@ -41,6 +46,106 @@ func (k *RSAPublicKey) SetEncodedKey(encodedKey string, encoding Encoding) (*RSA
 	return k, nil
 }
 
+// Set the key from PEM data, @see ParsePEMPublicKey .
+func (k *RSAPublicKey) SetPEMKey(pemKey []byte) (*RSAPublicKey, error) {
+	key, _, err := ParsePEMPublicKey(pemKey)
+	if err != nil {
+		return nil, err
+	}
+
+	k.publicKey = key
+	return k, nil
+}
+
+// Set the key from data in any supported representation, @see ParseAnyRSAKey .
+//		The public key of a private key is used.
+func (k *RSAPublicKey) SetKeyAuto(data []byte) (*RSAPublicKey, error) {
+	key, _, err := ParseAnyRSAKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		k.publicKey = &key.PublicKey
+	case *rsa.PublicKey:
+		k.publicKey = key
+	}
+	return k, nil
+}
+
+// Set the key from a certificate, @see VerifyCertificate to check it first.
+func (k *RSAPublicKey) SetCertificate(cert *x509.Certificate) (*RSAPublicKey, error) {
+	key, err := certificatePublicKey(cert)
+	if err != nil {
+		return nil, err
+	}
+
+	k.publicKey = key
+	return k, nil
+}
+
+// Set the key from an OpenSSH public key, @see ParseSSHPublicKey .
+func (k *RSAPublicKey) SetSSHKey(data []byte) (*RSAPublicKey, error) {
+	key, _, err := ParseSSHPublicKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	k.publicKey = key
+	return k, nil
+}
+
+// Set the key from a .NET RSAKeyValue XML document, @see ParseXMLPublicKey .
+func (k *RSAPublicKey) SetXMLKey(data []byte) (*RSAPublicKey, error) {
+	key, err := ParseXMLPublicKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	k.publicKey = key
+	return k, nil
+}
+
+// Set the key from JWK data, @see ParseJWK .
+//		The JWK alg, if any, also sets the signer or encrypter options.
+func (k *RSAPublicKey) SetJWK(data []byte) (*RSAPublicKey, error) {
+	jwk, err := ParseJWK(data)
+	if err != nil {
+		return nil, err
+	}
+	return k.setJWK(jwk)
+}
+
+// Set the key with the key ID kid from JWKS data, @see ParseJWKS .
+func (k *RSAPublicKey) SetJWKS(data []byte, kid string) (*RSAPublicKey, error) {
+	jwk, err := ParseJWKS(data, kid)
+	if err != nil {
+		return nil, err
+	}
+	return k.setJWK(jwk)
+}
+
+func (k *RSAPublicKey) setJWK(jwk *JWK) (*RSAPublicKey, error) {
+	signerOpts, encrypterOpts, err := jwkAlgorithmOpts(jwk.Alg)
+	if err != nil {
+		return nil, err
+	}
+	key, err := jwk.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	k.publicKey = key
+	if signerOpts != nil {
+		k.signerOpts = signerOpts
+	}
+	if encrypterOpts != nil {
+		k.encrypterOpts = encrypterOpts
+	}
+	return k, nil
+}
+
 func (k *RSAPublicKey) SetEncrypterOpts(opts EncrypterOpts) *RSAPublicKey {
 	k.encrypterOpts = opts
 	return k
@@ -61,11 +166,36 @@ func (k *RSAPublicKey) SetSignerHash(hash crypto.Hash) *RSAPublicKey {
 	return k
 }
 
+// Set the signature scheme and hash, so crypto/rsa is not needed to choose PKCS1 v1.5 or PSS, @see NewSignerOpts .
+func (k *RSAPublicKey) SetSignerScheme(scheme SignerScheme, hash crypto.Hash) *RSAPublicKey {
+	k.signerOpts = NewSignerOpts(scheme, hash)
+	return k
+}
+
+// Verify with RSASSA-PSS and hash, @see NewSignerOpts .
+//		saltLength is PSSSaltLengthAuto to accept any salt length, PSSSaltLengthEqualsHash or an exact length.
+func (k *RSAPublicKey) SetPSS(hash crypto.Hash, saltLength int) *RSAPublicKey {
+	k.signerOpts = &rsa.PSSOptions{Hash: hash, SaltLength: saltLength}
+	return k
+}
+
+// Set the number of goroutines used to encrypt chunks, @see RSAEncrypter.WithWorkers .
+func (k *RSAPublicKey) SetWorkers(n int) *RSAPublicKey {
+	k.workers = n
+	return k
+}
+
 func (k *RSAPublicKey) Encrypt(plain []byte) (cipher []byte, err error) {
+	return k.EncryptContext(context.Background(), plain)
+}
+
+// Same as Encrypt, but stops between chunks once ctx is done, @see RSAEncrypter.EncryptContext .
+func (k *RSAPublicKey) EncryptContext(ctx context.Context, plain []byte) (cipher []byte, err error) {
 	if k.publicKey == nil {
-		return nil, errors.New("rsacrypto: invalid public key")
+		return nil, ErrNoKey
 	}
-	return NewRSAEncrypter(k.publicKey, k.encrypterOpts).Encrypt(plain)
+	cipher, err = NewRSAEncrypter(k.publicKey, k.encrypterOpts).WithWorkers(k.workers).EncryptContext(ctx, plain)
+	return cipher, withKeyID(err, k.publicKey)
 }
 
 func (k *RSAPublicKey) EncryptAndEncode(plain []byte, encoding Encoding) (cipher string, err error) {
@@ -94,11 +224,28 @@ func (k *RSAPublicKey) EncryptObjectAndEncode(object interface{}, encoding Encod
 	return encoding.EncodeToString(b), nil
 }
 
+// Create a writer which encrypts the data written to it, @see RSAEncrypter.NewEncryptWriter .
+func (k *RSAPublicKey) NewEncryptWriter(w io.Writer) (io.WriteCloser, error) {
+	if k.publicKey == nil {
+		return nil, ErrNoKey
+	}
+	ew, err := NewRSAEncrypter(k.publicKey, k.encrypterOpts).NewEncryptWriter(w)
+	return ew, withKeyID(err, k.publicKey)
+}
+
 func (k *RSAPublicKey) Verify(data []byte, sign []byte) error {
+	return k.VerifyContext(context.Background(), data, sign)
+}
+
+// Same as Verify, but stops hashing once ctx is done, @see RSAVerifier.VerifyContext .
+func (k *RSAPublicKey) VerifyContext(ctx context.Context, data []byte, sign []byte) error {
+	if k.publicKey == nil {
+		return ErrNoKey
+	}
 	if k.signerOpts == nil {
-		return errors.New("rsacrypto: invalid signer options for verifier")
+		return withKeyID(ErrNoSignerOpts, k.publicKey)
 	}
-	return NewRSAVerifier(k.publicKey, k.signerOpts).Verify(data, sign)
+	return withKeyID(NewRSAVerifier(k.publicKey, k.signerOpts).VerifyContext(ctx, data, sign), k.publicKey)
 }
 
 func (k *RSAPublicKey) DecodeAndVerify(data []byte, sign string, encoding Encoding) error {
@@ -109,6 +256,146 @@ func (k *RSAPublicKey) DecodeAndVerify(data []byte, sign string, encoding Encodi
 	return k.Verify(data, b)
 }
 
+// Verify the sign of the data read from r, @see RSAVerifier.VerifyReader .
+func (k *RSAPublicKey) VerifyReader(r io.Reader, sign []byte) error {
+	if k.publicKey == nil {
+		return ErrNoKey
+	}
+	if k.signerOpts == nil {
+		return withKeyID(ErrNoSignerOpts, k.publicKey)
+	}
+	return withKeyID(NewRSAVerifier(k.publicKey, k.signerOpts).VerifyReader(r, sign), k.publicKey)
+}
+
+// Verify the sign of a digest computed elsewhere, @see RSAVerifier.VerifyDigest .
+func (k *RSAPublicKey) VerifyDigest(digest []byte, sign []byte) error {
+	if k.publicKey == nil {
+		return ErrNoKey
+	}
+	if k.signerOpts == nil {
+		return withKeyID(ErrNoSignerOpts, k.publicKey)
+	}
+	return withKeyID(NewRSAVerifier(k.publicKey, k.signerOpts).VerifyDigest(digest, sign), k.publicKey)
+}
+
+// Recover the hash algorithm and digest of a PKCS1 v1.5 signature, @see RSAVerifier.Recover .
+func (k *RSAPublicKey) Recover(sign []byte) (hash crypto.Hash, digest []byte, err error) {
+	if k.publicKey == nil {
+		return 0, nil, ErrNoKey
+	}
+	hash, digest, err = NewRSAVerifier(k.publicKey, k.signerOpts).Recover(sign)
+	return hash, digest, withKeyID(err, k.publicKey)
+}
+
+// Decrypt data encrypted with the private key, @see PublicDecrypt .
+func (k *RSAPublicKey) PublicDecrypt(cipher []byte) (plain []byte, err error) {
+	if k.publicKey == nil {
+		return nil, ErrNoKey
+	}
+	plain, err = publicDecrypt(context.Background(), k.publicKey, cipher, k.workers)
+	return plain, withKeyID(err, k.publicKey)
+}
+
+func (k *RSAPublicKey) DecodeAndPublicDecrypt(cipher string, encoding Encoding) (plain []byte, err error) {
+	b, err := encoding.DecodeString(cipher)
+	if err != nil {
+		return nil, err
+	}
+	return k.PublicDecrypt(b)
+}
+
+// Export the key to DER (binary) data, @see MarshalDERPublicKey .
+func (k *RSAPublicKey) MarshalDER(format KeyFormat) (der []byte, err error) {
+	if k.publicKey == nil {
+		return nil, ErrNoKey
+	}
+	return MarshalDERPublicKey(k.publicKey, format)
+}
+
+// Export the key to PEM data, @see MarshalPEMPublicKey .
+func (k *RSAPublicKey) MarshalPEM(format KeyFormat) (data []byte, err error) {
+	if k.publicKey == nil {
+		return nil, ErrNoKey
+	}
+	return MarshalPEMPublicKey(k.publicKey, format)
+}
+
+// Export the key to a string, the reverse of SetEncodedKey.
+func (k *RSAPublicKey) EncodeKey(format KeyFormat, encoding Encoding) (encodedKey string, err error) {
+	if k.publicKey == nil {
+		return "", ErrNoKey
+	}
+	return MarshalEncodedPublicKey(k.publicKey, format, encoding)
+}
+
+// Export the key to an OpenSSH public key line, @see MarshalSSHPublicKey .
+func (k *RSAPublicKey) MarshalSSH(info *SSHPublicKeyInfo) (data []byte, err error) {
+	if k.publicKey == nil {
+		return nil, ErrNoKey
+	}
+	return MarshalSSHPublicKey(k.publicKey, info)
+}
+
+// Export the key to a .NET RSAKeyValue XML document, @see MarshalXMLPublicKey .
+func (k *RSAPublicKey) MarshalXMLKey() (data []byte, err error) {
+	if k.publicKey == nil {
+		return nil, ErrNoKey
+	}
+	return MarshalXMLPublicKey(k.publicKey)
+}
+
+// Export the key to JWK data, params could be nil.
+func (k *RSAPublicKey) MarshalJWK(params *JWKParams) (data []byte, err error) {
+	if k.publicKey == nil {
+		return nil, ErrNoKey
+	}
+	jwk, err := NewPublicJWK(k.publicKey, params)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(jwk)
+}
+
+// The hash of the PKIX DER data of the key, @see Fingerprint .
+func (k *RSAPublicKey) Fingerprint(hash crypto.Hash) (fingerprint []byte, err error) {
+	if k.publicKey == nil {
+		return nil, ErrNoKey
+	}
+	return Fingerprint(k.publicKey, hash)
+}
+
+// The OpenSSH "SHA256:..." fingerprint of the key, @see SSHFingerprint .
+func (k *RSAPublicKey) SSHFingerprint() (fingerprint string, err error) {
+	if k.publicKey == nil {
+		return "", ErrNoKey
+	}
+	return SSHFingerprint(k.publicKey)
+}
+
+// The legacy OpenSSH MD5 fingerprint of the key, @see SSHFingerprintMD5 .
+func (k *RSAPublicKey) SSHFingerprintMD5() (fingerprint string, err error) {
+	if k.publicKey == nil {
+		return "", ErrNoKey
+	}
+	return SSHFingerprintMD5(k.publicKey)
+}
+
+// The RFC 7638 JWK thumbprint of the key, @see JWKThumbprint .
+func (k *RSAPublicKey) JWKThumbprint(hash crypto.Hash) (thumbprint []byte, err error) {
+	if k.publicKey == nil {
+		return nil, ErrNoKey
+	}
+	return JWKThumbprint(k.publicKey, hash)
+}
+
+// A short stable identifier of the key, @see KeyID . Empty if the key is not set.
+func (k *RSAPublicKey) KeyID() string {
+	if k.publicKey == nil {
+		return ""
+	}
+	return KeyID(k.publicKey)
+}
+
 type UnmarshalFunc func(data []byte, v interface{}) error
 
 // A wrapper for decrypt and sign.
@@ -117,6 +404,7 @@ type RSAPrivateKey struct {
 	decrypterOpts DecrypterOpts
 	unmarshalFunc UnmarshalFunc // Used to decrypt an object, could be one of json.Unmarshal/xml.Unmarshal/yaml.Unmarshal .
 	signerOpts    crypto.SignerOpts
+	workers       int
 }
 
 func NewRSAPrivateKey() *RSAPrivateKey {
@@ -125,6 +413,7 @@ func NewRSAPrivateKey() *RSAPrivateKey {
 		decrypterOpts: nil,
 		unmarshalFunc: json.Unmarshal,
 		signerOpts:    nil,
+		workers:       0,
 	}
 }
 
@@ -143,6 +432,94 @@ func (k *RSAPrivateKey) SetEncodedKey(encodedKey string, encoding Encoding) (*RS
 	return k, nil
 }
 
+// Set the key from PEM data, @see ParsePEMPrivateKey .
+func (k *RSAPrivateKey) SetPEMKey(pemKey []byte) (*RSAPrivateKey, error) {
+	key, _, err := ParsePEMPrivateKey(pemKey)
+	if err != nil {
+		return nil, err
+	}
+
+	k.privateKey = key
+	return k, nil
+}
+
+// Set the key from encrypted PEM data, @see ParseEncryptedPEMPrivateKey .
+func (k *RSAPrivateKey) SetEncryptedPEMKey(pemKey []byte, password []byte) (*RSAPrivateKey, error) {
+	key, _, err := ParseEncryptedPEMPrivateKey(pemKey, password)
+	if err != nil {
+		return nil, err
+	}
+
+	k.privateKey = key
+	return k, nil
+}
+
+// Set the key from data in any supported representation, @see ParseAnyRSAKey .
+//		Data holding only a public key returns an error matching ErrKeyFormat.
+func (k *RSAPrivateKey) SetKeyAuto(data []byte) (*RSAPrivateKey, error) {
+	key, info, err := ParseAnyRSAKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w, got a %s", ErrKeyFormat, info)
+	}
+	k.privateKey = privateKey
+	return k, nil
+}
+
+// Set the key from a .NET RSAKeyValue XML document, @see ParseXMLPrivateKey .
+func (k *RSAPrivateKey) SetXMLKey(data []byte) (*RSAPrivateKey, error) {
+	key, err := ParseXMLPrivateKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	k.privateKey = key
+	return k, nil
+}
+
+// Set the key from JWK data, @see ParseJWK .
+//		The JWK alg, if any, also sets the signer or decrypter options.
+func (k *RSAPrivateKey) SetJWK(data []byte) (*RSAPrivateKey, error) {
+	jwk, err := ParseJWK(data)
+	if err != nil {
+		return nil, err
+	}
+	return k.setJWK(jwk)
+}
+
+// Set the key with the key ID kid from JWKS data, @see ParseJWKS .
+func (k *RSAPrivateKey) SetJWKS(data []byte, kid string) (*RSAPrivateKey, error) {
+	jwk, err := ParseJWKS(data, kid)
+	if err != nil {
+		return nil, err
+	}
+	return k.setJWK(jwk)
+}
+
+func (k *RSAPrivateKey) setJWK(jwk *JWK) (*RSAPrivateKey, error) {
+	signerOpts, decrypterOpts, err := jwkAlgorithmOpts(jwk.Alg)
+	if err != nil {
+		return nil, err
+	}
+	key, err := jwk.PrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	k.privateKey = key
+	if signerOpts != nil {
+		k.signerOpts = signerOpts
+	}
+	if decrypterOpts != nil {
+		k.decrypterOpts = decrypterOpts
+	}
+	return k, nil
+}
+
 func (k *RSAPrivateKey) SetDecrypterOpts(opts DecrypterOpts) *RSAPrivateKey {
 	k.decrypterOpts = opts
 	return k
@@ -163,11 +540,36 @@ func (k *RSAPrivateKey) SetSignerHash(hash crypto.Hash) *RSAPrivateKey {
 	return k
 }
 
+// Set the signature scheme and hash, so crypto/rsa is not needed to choose PKCS1 v1.5 or PSS, @see NewSignerOpts .
+func (k *RSAPrivateKey) SetSignerScheme(scheme SignerScheme, hash crypto.Hash) *RSAPrivateKey {
+	k.signerOpts = NewSignerOpts(scheme, hash)
+	return k
+}
+
+// Sign with RSASSA-PSS and hash, @see NewSignerOpts .
+//		saltLength is PSSSaltLengthEqualsHash for most verifiers, PSSSaltLengthAuto for the largest salt, or an exact length.
+func (k *RSAPrivateKey) SetPSS(hash crypto.Hash, saltLength int) *RSAPrivateKey {
+	k.signerOpts = &rsa.PSSOptions{Hash: hash, SaltLength: saltLength}
+	return k
+}
+
+// Set the number of goroutines used to decrypt chunks, @see RSADecrypter.WithWorkers .
+func (k *RSAPrivateKey) SetWorkers(n int) *RSAPrivateKey {
+	k.workers = n
+	return k
+}
+
 func (k *RSAPrivateKey) Decrypt(cipher []byte) (plain []byte, err error) {
+	return k.DecryptContext(context.Background(), cipher)
+}
+
+// Same as Decrypt, but stops between chunks once ctx is done, @see RSADecrypter.DecryptContext .
+func (k *RSAPrivateKey) DecryptContext(ctx context.Context, cipher []byte) (plain []byte, err error) {
 	if k.privateKey == nil {
-		return nil, errors.New("rsacrypto: invalid private key")
+		return nil, ErrNoKey
 	}
-	return NewRSADecrypter(k.privateKey, k.decrypterOpts).Decrypt(cipher);
+	plain, err = NewRSADecrypter(k.privateKey, k.decrypterOpts).WithWorkers(k.workers).DecryptContext(ctx, cipher)
+	return plain, withKeyID(err, &k.privateKey.PublicKey)
 }
 
 func (k *RSAPrivateKey) DecodeAndDecrypt(cipher string, encoding Encoding) (plain []byte, err error) {
@@ -194,11 +596,29 @@ func (k *RSAPrivateKey) DecodeAndDecryptToObject(cipher string, encoding Encodin
 	return k.DecryptToObject(b, object)
 }
 
+// Create a reader which decrypts the data read from r, @see RSADecrypter.NewDecryptReader .
+func (k *RSAPrivateKey) NewDecryptReader(r io.Reader) (io.Reader, error) {
+	if k.privateKey == nil {
+		return nil, ErrNoKey
+	}
+	dr, err := NewRSADecrypter(k.privateKey, k.decrypterOpts).NewDecryptReader(r)
+	return dr, withKeyID(err, &k.privateKey.PublicKey)
+}
+
 func (k *RSAPrivateKey) Sign(data []byte) (sign []byte, err error) {
+	return k.SignContext(context.Background(), data)
+}
+
+// Same as Sign, but stops hashing once ctx is done, @see RSASigner.SignContext .
+func (k *RSAPrivateKey) SignContext(ctx context.Context, data []byte) (sign []byte, err error) {
+	if k.privateKey == nil {
+		return nil, ErrNoKey
+	}
 	if k.signerOpts == nil {
-		return nil, errors.New("rsacrypto: invalid signer options for signer")
+		return nil, withKeyID(ErrNoSignerOpts, &k.privateKey.PublicKey)
 	}
-	return NewRSASigner(k.privateKey, k.signerOpts).Sign(data)
+	sign, err = NewRSASigner(k.privateKey, k.signerOpts).SignContext(ctx, data)
+	return sign, withKeyID(err, &k.privateKey.PublicKey)
 }
 
 func (k *RSAPrivateKey) SignAndEncode(data []byte, encoding Encoding) (sign string, err error) {
@@ -207,4 +627,157 @@ func (k *RSAPrivateKey) SignAndEncode(data []byte, encoding Encoding) (sign stri
 		return "", err
 	}
 	return encoding.EncodeToString(b), nil
-}
\ No newline at end of file
+}
+
+// Sign a digest computed elsewhere, @see RSASigner.SignDigest .
+func (k *RSAPrivateKey) SignDigest(digest []byte) (sign []byte, err error) {
+	if k.privateKey == nil {
+		return nil, ErrNoKey
+	}
+	if k.signerOpts == nil {
+		return nil, withKeyID(ErrNoSignerOpts, &k.privateKey.PublicKey)
+	}
+	sign, err = NewRSASigner(k.privateKey, k.signerOpts).SignDigest(digest)
+	return sign, withKeyID(err, &k.privateKey.PublicKey)
+}
+
+// Sign the data read from r, @see RSASigner.SignReader .
+func (k *RSAPrivateKey) SignReader(r io.Reader) (sign []byte, err error) {
+	if k.privateKey == nil {
+		return nil, ErrNoKey
+	}
+	if k.signerOpts == nil {
+		return nil, withKeyID(ErrNoSignerOpts, &k.privateKey.PublicKey)
+	}
+	sign, err = NewRSASigner(k.privateKey, k.signerOpts).SignReader(r)
+	return sign, withKeyID(err, &k.privateKey.PublicKey)
+}
+
+// Create a writer which hashes the data written to it for a sign, @see RSASigner.NewSigningWriter .
+func (k *RSAPrivateKey) NewSigningWriter(w io.Writer) (*SigningWriter, error) {
+	if k.privateKey == nil {
+		return nil, ErrNoKey
+	}
+	if k.signerOpts == nil {
+		return nil, withKeyID(ErrNoSignerOpts, &k.privateKey.PublicKey)
+	}
+	sw, err := NewRSASigner(k.privateKey, k.signerOpts).NewSigningWriter(w)
+	return sw, withKeyID(err, &k.privateKey.PublicKey)
+}
+
+// Encrypt with the private key, so the data could be recovered with the public key, @see PrivateEncrypt .
+func (k *RSAPrivateKey) PrivateEncrypt(plain []byte) (cipher []byte, err error) {
+	if k.privateKey == nil {
+		return nil, ErrNoKey
+	}
+	cipher, err = privateEncrypt(context.Background(), k.privateKey, plain, k.workers)
+	return cipher, withKeyID(err, &k.privateKey.PublicKey)
+}
+
+func (k *RSAPrivateKey) PrivateEncryptAndEncode(plain []byte, encoding Encoding) (cipher string, err error) {
+	b, err := k.PrivateEncrypt(plain)
+	if err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(b), nil
+}
+
+// Export the key to DER (binary) data, @see MarshalDERPrivateKey .
+func (k *RSAPrivateKey) MarshalDER(format KeyFormat) (der []byte, err error) {
+	if k.privateKey == nil {
+		return nil, ErrNoKey
+	}
+	return MarshalDERPrivateKey(k.privateKey, format)
+}
+
+// Export the key to PEM data, @see MarshalPEMPrivateKey .
+func (k *RSAPrivateKey) MarshalPEM(format KeyFormat) (data []byte, err error) {
+	if k.privateKey == nil {
+		return nil, ErrNoKey
+	}
+	return MarshalPEMPrivateKey(k.privateKey, format)
+}
+
+// Export the key to a string, the reverse of SetEncodedKey.
+func (k *RSAPrivateKey) EncodeKey(format KeyFormat, encoding Encoding) (encodedKey string, err error) {
+	if k.privateKey == nil {
+		return "", ErrNoKey
+	}
+	return MarshalEncodedPrivateKey(k.privateKey, format, encoding)
+}
+
+// Export the key to an "ENCRYPTED PRIVATE KEY" PEM block, @see MarshalEncryptedPEMPrivateKey .
+func (k *RSAPrivateKey) MarshalEncryptedPEM(password []byte, params *PBES2Params) (data []byte, err error) {
+	if k.privateKey == nil {
+		return nil, ErrNoKey
+	}
+	return MarshalEncryptedPEMPrivateKey(k.privateKey, password, params)
+}
+
+// Export the key to an "OPENSSH PRIVATE KEY" PEM block, @see MarshalSSHPrivateKey .
+func (k *RSAPrivateKey) MarshalSSH(comment string, password []byte) (data []byte, err error) {
+	if k.privateKey == nil {
+		return nil, ErrNoKey
+	}
+	return MarshalSSHPrivateKey(k.privateKey, comment, password)
+}
+
+// Export the key to a .NET RSAKeyValue XML document, @see MarshalXMLPrivateKey .
+func (k *RSAPrivateKey) MarshalXMLKey() (data []byte, err error) {
+	if k.privateKey == nil {
+		return nil, ErrNoKey
+	}
+	return MarshalXMLPrivateKey(k.privateKey)
+}
+
+// Create a certificate signing request of the key, @see CreateCertificateRequest .
+func (k *RSAPrivateKey) CreateCertificateRequest(template *x509.CertificateRequest) (der []byte, err error) {
+	if k.privateKey == nil {
+		return nil, ErrNoKey
+	}
+	return CreateCertificateRequest(k.privateKey, template)
+}
+
+// Create a self-signed certificate of the key, @see CreateSelfSignedCertificate .
+func (k *RSAPrivateKey) CreateSelfSignedCertificate(template *x509.Certificate) (der []byte, err error) {
+	if k.privateKey == nil {
+		return nil, ErrNoKey
+	}
+	return CreateSelfSignedCertificate(k.privateKey, template)
+}
+
+// Export the key and its certificate chain to a PKCS12 keystore, @see MarshalPKCS12 .
+func (k *RSAPrivateKey) MarshalPKCS12(cert *x509.Certificate, caCerts []*x509.Certificate, password []byte) (data []byte, err error) {
+	if k.privateKey == nil {
+		return nil, ErrNoKey
+	}
+	return MarshalPKCS12(k.privateKey, cert, caCerts, password)
+}
+
+// Export the key to JWK data including the private members, params could be nil.
+func (k *RSAPrivateKey) MarshalJWK(params *JWKParams) (data []byte, err error) {
+	if k.privateKey == nil {
+		return nil, ErrNoKey
+	}
+	jwk, err := NewPrivateJWK(k.privateKey, params)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(jwk)
+}
+
+// The hash of the PKIX DER data of the public key, @see Fingerprint .
+func (k *RSAPrivateKey) Fingerprint(hash crypto.Hash) (fingerprint []byte, err error) {
+	if k.privateKey == nil {
+		return nil, ErrNoKey
+	}
+	return Fingerprint(&k.privateKey.PublicKey, hash)
+}
+
+// A short stable identifier of the key, the same as the KeyID of its public key. Empty if the key is not set.
+func (k *RSAPrivateKey) KeyID() string {
+	if k.privateKey == nil {
+		return ""
+	}
+	return KeyID(&k.privateKey.PublicKey)
+}
@@ -1,7 +1,9 @@
 package rsacrypto
 
 import (
+	"context"
 	"crypto"
+	"crypto/rsa"
 	"encoding/base64"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -193,3 +195,146 @@ This is a very very long message. 这是一段很长很长的消息。This is a
 		}
 	}
 }
+
+func TestRSAPublicKey_EncodeKey(t *testing.T) {
+	for _, key := range testKeys {
+		pubKey, err := NewRSAPublicKey().SetEncodedKey(key.PublicKey, nil)
+		assert.Nil(t, err)
+
+		for _, format := range []KeyFormat{FormatPKIX, FormatPKCS1} {
+			encoded, err := pubKey.EncodeKey(format, HexEncoding)
+			assert.Nil(t, err)
+			decoded, err := NewRSAPublicKey().SetEncodedKey(encoded, HexEncoding)
+			assert.Nil(t, err)
+			assert.Equal(t, pubKey.publicKey, decoded.publicKey)
+
+			data, err := pubKey.MarshalPEM(format)
+			assert.Nil(t, err)
+			_, info, err := ParsePEMPublicKey(data)
+			assert.Nil(t, err)
+			assert.Equal(t, format, info.Format)
+		}
+
+		_, err = pubKey.MarshalDER(FormatPKCS8)
+		assert.NotNil(t, err)
+	}
+
+	_, err := NewRSAPublicKey().MarshalDER(FormatPKIX)
+	assert.NotNil(t, err)
+}
+
+func TestRSAPrivateKey_EncodeKey(t *testing.T) {
+	for _, key := range testKeys {
+		privKey, err := NewRSAPrivateKey().SetEncodedKey(key.PrivateKey, nil)
+		assert.Nil(t, err)
+
+		for _, format := range []KeyFormat{FormatPKCS8, FormatPKCS1} {
+			encoded, err := privKey.EncodeKey(format, base64.StdEncoding)
+			assert.Nil(t, err)
+			decoded, err := NewRSAPrivateKey().SetEncodedKey(encoded, base64.StdEncoding)
+			assert.Nil(t, err)
+			assert.Equal(t, privKey.privateKey.D, decoded.privateKey.D)
+
+			data, err := privKey.MarshalPEM(format)
+			assert.Nil(t, err)
+			_, info, err := ParsePEMPrivateKey(data)
+			assert.Nil(t, err)
+			assert.Equal(t, format, info.Format)
+		}
+
+		_, err = privKey.MarshalDER(FormatPKIX)
+		assert.NotNil(t, err)
+	}
+}
+
+func TestRSAPrivateKey_SignContext(t *testing.T) {
+	pubKey, err := NewRSAPublicKey().SetEncodedKey(testKeys[0].PublicKey, nil)
+	assert.Nil(t, err)
+	privKey, err := NewRSAPrivateKey().SetEncodedKey(testKeys[0].PrivateKey, nil)
+	assert.Nil(t, err)
+	pubKey.SetSignerHash(crypto.SHA256)
+	privKey.SetSignerHash(crypto.SHA256)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sign, err := privKey.SignContext(ctx, []byte(`A short message`))
+	assert.Nil(t, err)
+	assert.Nil(t, pubKey.VerifyContext(ctx, []byte(`A short message`), sign))
+	cipher, err := pubKey.EncryptContext(ctx, []byte(`A short message`))
+	assert.Nil(t, err)
+
+	cancel()
+	_, err = privKey.DecryptContext(ctx, cipher)
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = privKey.SignContext(ctx, []byte(`A short message`))
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRSAPrivateKey_SetPSS(t *testing.T) {
+	pubKey, err := NewRSAPublicKey().SetEncodedKey(testKeys[0].PublicKey, nil)
+	assert.Nil(t, err)
+	privKey, err := NewRSAPrivateKey().SetEncodedKey(testKeys[0].PrivateKey, nil)
+	assert.Nil(t, err)
+	msg := []byte(`A short message`)
+
+	// The largest salt is only accepted by a verifier of any salt length.
+	sign, err := privKey.SetPSS(crypto.SHA256, PSSSaltLengthAuto).Sign(msg)
+	assert.Nil(t, err)
+	assert.Nil(t, pubKey.SetPSS(crypto.SHA256, PSSSaltLengthAuto).Verify(msg, sign))
+	assert.ErrorIs(t, pubKey.SetPSS(crypto.SHA256, PSSSaltLengthEqualsHash).Verify(msg, sign), rsa.ErrVerification)
+
+	// An exact salt length.
+	sign, err = privKey.SetPSS(crypto.SHA256, 20).Sign(msg)
+	assert.Nil(t, err)
+	assert.Nil(t, pubKey.SetPSS(crypto.SHA256, 20).Verify(msg, sign))
+	assert.Nil(t, pubKey.SetPSS(crypto.SHA256, PSSSaltLengthAuto).Verify(msg, sign))
+	assert.ErrorIs(t, pubKey.SetPSS(crypto.SHA256, 32).Verify(msg, sign), rsa.ErrVerification)
+
+	// SetSignerHash always selects PKCS1 v1.5.
+	sign, err = privKey.SetSignerScheme(SchemePSS, crypto.SHA512).Sign(msg)
+	assert.Nil(t, err)
+	assert.Equal(t, &rsa.PSSOptions{Hash: crypto.SHA512, SaltLength: PSSSaltLengthEqualsHash}, privKey.signerOpts)
+	assert.Nil(t, pubKey.SetSignerScheme(SchemePSS, crypto.SHA512).Verify(msg, sign))
+	assert.NotNil(t, pubKey.SetSignerHash(crypto.SHA512).Verify(msg, sign))
+	assert.Equal(t, &DefaultSignerOpts{Hash: crypto.SHA512}, pubKey.signerOpts)
+
+	sign, err = privKey.SetSignerScheme(SchemePKCS1v15, crypto.SHA256).Sign(msg)
+	assert.Nil(t, err)
+	assert.Nil(t, pubKey.SetSignerHash(crypto.SHA256).Verify(msg, sign))
+
+	// An unknown scheme is reported where it is used.
+	_, err = privKey.SetSignerScheme(SignerScheme(99), crypto.SHA256).Sign(msg)
+	assert.ErrorIs(t, err, ErrUnsupportedOpts)
+	assert.ErrorContains(t, err, "unknown signer scheme 99")
+	_, err = privKey.SignDigest(make([]byte, crypto.SHA256.Size()))
+	assert.ErrorIs(t, err, ErrUnsupportedOpts)
+	assert.ErrorIs(t, pubKey.SetSignerScheme(SignerScheme(99), crypto.SHA256).Verify(msg, sign), ErrUnsupportedOpts)
+	assert.Equal(t, "PSS", SchemePSS.String())
+	assert.Equal(t, "unknown", SignerScheme(99).String())
+}
+
+func TestRSAPublicKey_Errors(t *testing.T) {
+	_, err := NewRSAPublicKey().Encrypt([]byte(`A short message`))
+	assert.ErrorIs(t, err, ErrNoKey)
+	_, err = NewRSAPrivateKey().Decrypt([]byte(`A short message`))
+	assert.ErrorIs(t, err, ErrNoKey)
+
+	pubKey, err := NewRSAPublicKey().SetEncodedKey(testKeys[0].PublicKey, nil)
+	assert.Nil(t, err)
+	err = pubKey.Verify([]byte(`A short message`), nil)
+	assert.ErrorIs(t, err, ErrNoSignerOpts)
+	_, err = pubKey.SetEncrypterOpts("invalid").Encrypt([]byte(`A short message`))
+	assert.ErrorIs(t, err, ErrUnsupportedOpts)
+
+	// OAEP with SHA512 needs more than a 1024 bits key.
+	pubKey, err = NewRSAPublicKey().SetEncodedKey(testKeys[1].PublicKey, nil)
+	assert.Nil(t, err)
+	_, err = pubKey.SetEncrypterOpts(&rsa.OAEPOptions{Hash: crypto.SHA512}).Encrypt([]byte(`A short message`))
+	assert.ErrorIs(t, err, ErrChunkSize)
+
+	privKey, err := NewRSAPrivateKey().SetEncodedKey(testKeys[1].PrivateKey, nil)
+	assert.Nil(t, err)
+	_, err = privKey.Decrypt(make([]byte, 100))
+	assert.ErrorIs(t, err, ErrChunkSize)
+	_, err = privKey.MarshalDER(FormatPKIX)
+	assert.ErrorIs(t, err, ErrKeyFormat)
+}
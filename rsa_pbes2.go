@@ -0,0 +1,498 @@
+package rsacrypto
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/scrypt"
+	"hash"
+	"io"
+)
+
+// PEM block type of encrypted PKCS8 private keys.
+const PEMTypeEncryptedPrivateKey = "ENCRYPTED PRIVATE KEY"
+
+// The key derivation function of PBES2.
+type KDF int
+
+const (
+	KDFPBKDF2 KDF = iota // PBKDF2 of PKCS5, @see https://tools.ietf.org/html/rfc8018 .
+	KDFScrypt            // scrypt, @see https://tools.ietf.org/html/rfc7914 .
+)
+
+// The cipher of PBES2.
+//		The GCM ciphers (RFC 5084) are not supported by OpenSSL, prefer CBC for keys shared with other tools.
+type PBECipher int
+
+const (
+	PBEAES256CBC PBECipher = iota
+	PBEAES192CBC
+	PBEAES128CBC
+	PBEAES256GCM
+	PBEAES192GCM
+	PBEAES128GCM
+)
+
+// Parameters to encrypt a private key with PBES2, zero fields use the defaults of DefaultPBES2Params.
+type PBES2Params struct {
+	KDF    KDF
+	Cipher PBECipher
+
+	// PBKDF2 parameters.
+	Iterations int         // The iteration count.
+	PRF        crypto.Hash // The HMAC hash, one of SHA1/SHA224/SHA256/SHA384/SHA512.
+
+	// scrypt parameters.
+	ScryptN int // CPU/memory cost, a power of 2.
+	ScryptR int // Block size.
+	ScryptP int // Parallelization.
+
+	SaltSize int
+}
+
+// The default parameters: PBKDF2 with HMAC-SHA256 and AES-256-CBC like OpenSSL 3, with a larger iteration count.
+var DefaultPBES2Params = PBES2Params{
+	KDF:        KDFPBKDF2,
+	Cipher:     PBEAES256CBC,
+	Iterations: 600000,
+	PRF:        crypto.SHA256,
+	ScryptN:    1 << 15,
+	ScryptR:    8,
+	ScryptP:    1,
+	SaltSize:   16,
+}
+
+// Limits of the KDF parameters, so parsing an untrusted key could not exhaust the CPU or memory.
+//		scrypt uses 128*N*r bytes of memory, 1 GiB at most.
+const (
+	maxPBKDF2Iterations = 10000000
+	maxScryptN          = 1 << 20
+	maxScryptR          = 32
+	maxScryptP          = 16
+	maxScryptNR         = 1 << 23
+)
+
+var (
+	oidPBES2  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidScrypt = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11591, 4, 11}
+
+	oidHMACWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACWithSHA224 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 8}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHMACWithSHA384 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 10}
+	oidHMACWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+
+	oidAES128CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+	oidAES128GCM = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 6}
+	oidAES192GCM = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 26}
+	oidAES256GCM = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 46}
+)
+
+// ASN.1 structures, @see https://tools.ietf.org/html/rfc5958#section-3 and https://tools.ietf.org/html/rfc8018#appendix-A .
+type encryptedPrivateKeyInfo struct {
+	Algorithm     pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+type scryptParams struct {
+	Salt                     []byte
+	CostParameter            int
+	BlockSize                int
+	ParallelizationParameter int
+	KeyLength                int `asn1:"optional"`
+}
+
+type gcmParams struct {
+	Nonce  []byte
+	ICVLen int `asn1:"default:12"`
+}
+
+type pbeCipherInfo struct {
+	oid     asn1.ObjectIdentifier
+	keySize int
+	gcm     bool
+}
+
+var pbeCiphers = map[PBECipher]pbeCipherInfo{
+	PBEAES128CBC: {oidAES128CBC, 16, false},
+	PBEAES192CBC: {oidAES192CBC, 24, false},
+	PBEAES256CBC: {oidAES256CBC, 32, false},
+	PBEAES128GCM: {oidAES128GCM, 16, true},
+	PBEAES192GCM: {oidAES192GCM, 24, true},
+	PBEAES256GCM: {oidAES256GCM, 32, true},
+}
+
+var pbePRFs = map[crypto.Hash]asn1.ObjectIdentifier{
+	crypto.SHA1:   oidHMACWithSHA1,
+	crypto.SHA224: oidHMACWithSHA224,
+	crypto.SHA256: oidHMACWithSHA256,
+	crypto.SHA384: oidHMACWithSHA384,
+	crypto.SHA512: oidHMACWithSHA512,
+}
+
+// Parse rsa private key from encrypted PKCS8 DER (binary) data, the content of an "ENCRYPTED PRIVATE KEY" PEM block.
+//		Only PBES2 is supported, with PBKDF2 or scrypt and AES-CBC or AES-GCM.
+//		An incorrect password returns an error matching ErrPassword.
+func ParseEncryptedPrivateKey(der []byte, password []byte) (key *rsa.PrivateKey, err error) {
+	plain, err := decryptPBES2(der, password)
+	if err != nil {
+		return nil, err
+	}
+
+	key, _, err = parseDERPrivateKey(plain)
+	return key, err
+}
+
+// Marshal rsa private key to encrypted PKCS8 DER (binary) data.
+//		params could be nil to use DefaultPBES2Params.
+func MarshalEncryptedPrivateKey(key *rsa.PrivateKey, password []byte, params *PBES2Params) (der []byte, err error) {
+	plain, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return encryptPBES2(plain, password, params)
+}
+
+// Marshal rsa private key to an "ENCRYPTED PRIVATE KEY" PEM block.
+func MarshalEncryptedPEMPrivateKey(key *rsa.PrivateKey, password []byte, params *PBES2Params) (data []byte, err error) {
+	der, err := MarshalEncryptedPrivateKey(key, password, params)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: PEMTypeEncryptedPrivateKey, Bytes: der}), nil
+}
+
+func (params *PBES2Params) withDefaults() PBES2Params {
+	p := DefaultPBES2Params
+	if params == nil {
+		return p
+	}
+	p.KDF = params.KDF
+	p.Cipher = params.Cipher
+	if params.Iterations > 0 {
+		p.Iterations = params.Iterations
+	}
+	if params.PRF != 0 {
+		p.PRF = params.PRF
+	}
+	if params.ScryptN > 0 {
+		p.ScryptN = params.ScryptN
+	}
+	if params.ScryptR > 0 {
+		p.ScryptR = params.ScryptR
+	}
+	if params.ScryptP > 0 {
+		p.ScryptP = params.ScryptP
+	}
+	if params.SaltSize > 0 {
+		p.SaltSize = params.SaltSize
+	}
+	return p
+}
+
+func encryptPBES2(plain []byte, password []byte, params *PBES2Params) ([]byte, error) {
+	p := params.withDefaults()
+	cipherInfo, ok := pbeCiphers[p.Cipher]
+	if !ok {
+		return nil, fmt.Errorf("%w, unknown PBES2 cipher %d", ErrUnsupportedOpts, p.Cipher)
+	}
+
+	salt := make([]byte, p.SaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+
+	var kdf pkix.AlgorithmIdentifier
+	var key []byte
+	var err error
+	switch p.KDF {
+	case KDFPBKDF2:
+		prfOID, ok := pbePRFs[p.PRF]
+		if !ok {
+			return nil, fmt.Errorf("%w, unsupported PBKDF2 PRF %v", ErrUnsupportedOpts, p.PRF)
+		}
+		if err := checkPBKDF2Params(p.Iterations); err != nil {
+			return nil, fmt.Errorf("%w, %v", ErrUnsupportedOpts, err)
+		}
+		key, err = pbkdf2.Key(p.PRF.New, string(password), salt, p.Iterations, cipherInfo.keySize)
+		if err != nil {
+			return nil, err
+		}
+		kdf.Algorithm = oidPBKDF2
+		kdf.Parameters, err = marshalAsn1Value(pbkdf2Params{
+			Salt:           salt,
+			IterationCount: p.Iterations,
+			PRF:            pkix.AlgorithmIdentifier{Algorithm: prfOID, Parameters: asn1.NullRawValue},
+		})
+	case KDFScrypt:
+		if err := checkScryptParams(p.ScryptN, p.ScryptR, p.ScryptP); err != nil {
+			return nil, fmt.Errorf("%w, %v", ErrUnsupportedOpts, err)
+		}
+		key, err = scrypt.Key(password, salt, p.ScryptN, p.ScryptR, p.ScryptP, cipherInfo.keySize)
+		if err != nil {
+			return nil, err
+		}
+		kdf.Algorithm = oidScrypt
+		kdf.Parameters, err = marshalAsn1Value(scryptParams{
+			Salt:                     salt,
+			CostParameter:            p.ScryptN,
+			BlockSize:                p.ScryptR,
+			ParallelizationParameter: p.ScryptP,
+		})
+	default:
+		return nil, fmt.Errorf("%w, unknown PBES2 KDF %d", ErrUnsupportedOpts, p.KDF)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	scheme := pkix.AlgorithmIdentifier{Algorithm: cipherInfo.oid}
+	var encrypted []byte
+	if cipherInfo.gcm {
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		nonce := make([]byte, aead.NonceSize())
+		if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+			return nil, err
+		}
+		scheme.Parameters, err = marshalAsn1Value(gcmParams{Nonce: nonce, ICVLen: aead.Overhead()})
+		if err != nil {
+			return nil, err
+		}
+		encrypted = aead.Seal(nil, nonce, plain, nil)
+	} else {
+		iv := make([]byte, block.BlockSize())
+		if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+			return nil, err
+		}
+		scheme.Parameters, err = marshalAsn1Value(iv)
+		if err != nil {
+			return nil, err
+		}
+		encrypted = pkcs7Pad(plain, block.BlockSize())
+		cipher.NewCBCEncrypter(block, iv).CryptBlocks(encrypted, encrypted)
+	}
+
+	pbes2, err := marshalAsn1Value(pbes2Params{KeyDerivationFunc: kdf, EncryptionScheme: scheme})
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(encryptedPrivateKeyInfo{
+		Algorithm:     pkix.AlgorithmIdentifier{Algorithm: oidPBES2, Parameters: pbes2},
+		EncryptedData: encrypted,
+	})
+}
+
+func decryptPBES2(der []byte, password []byte) ([]byte, error) {
+	var info encryptedPrivateKeyInfo
+	if err := unmarshalAsn1(der, &info); err != nil {
+		return nil, fmt.Errorf("%w, invalid encrypted private key: %v", ErrKeyFormat, err)
+	}
+	if !info.Algorithm.Algorithm.Equal(oidPBES2) {
+		return nil, fmt.Errorf("%w, unsupported encryption algorithm %v", ErrKeyFormat, info.Algorithm.Algorithm)
+	}
+	var params pbes2Params
+	if err := unmarshalAsn1(info.Algorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, fmt.Errorf("%w, invalid PBES2 parameters: %v", ErrKeyFormat, err)
+	}
+
+	var cipherInfo *pbeCipherInfo
+	for _, c := range pbeCiphers {
+		if c.oid.Equal(params.EncryptionScheme.Algorithm) {
+			cipherInfo = &c
+			break
+		}
+	}
+	if cipherInfo == nil {
+		return nil, fmt.Errorf("%w, unsupported PBES2 cipher %v", ErrKeyFormat, params.EncryptionScheme.Algorithm)
+	}
+
+	key, err := deriveKey(params.KeyDerivationFunc, password, cipherInfo.keySize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted := info.EncryptedData
+	if cipherInfo.gcm {
+		var gcm gcmParams
+		if err := unmarshalAsn1(params.EncryptionScheme.Parameters.FullBytes, &gcm); err != nil {
+			return nil, fmt.Errorf("%w, invalid GCM parameters: %v", ErrKeyFormat, err)
+		}
+		aead, err := cipher.NewGCMWithNonceSize(block, len(gcm.Nonce))
+		if err != nil {
+			return nil, err
+		}
+		if gcm.ICVLen != aead.Overhead() {
+			aead, err = cipher.NewGCMWithTagSize(block, gcm.ICVLen)
+			if err != nil || len(gcm.Nonce) != aead.NonceSize() {
+				return nil, fmt.Errorf("%w, unsupported GCM parameters", ErrKeyFormat)
+			}
+		}
+		plain, err := aead.Open(nil, gcm.Nonce, encrypted, nil)
+		if err != nil {
+			return nil, ErrPassword
+		}
+		return plain, nil
+	}
+
+	var iv []byte
+	if err := unmarshalAsn1(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil || len(iv) != block.BlockSize() {
+		return nil, fmt.Errorf("%w, invalid CBC parameters", ErrKeyFormat)
+	}
+	if len(encrypted) == 0 || len(encrypted)%block.BlockSize() != 0 {
+		return nil, fmt.Errorf("%w, invalid encrypted data size %d", ErrKeyFormat, len(encrypted))
+	}
+	plain := make([]byte, len(encrypted))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plain, encrypted)
+	plain, err = pkcs7Unpad(plain, block.BlockSize())
+	if err != nil || !isDERSequence(plain) {
+		return nil, ErrPassword
+	}
+	return plain, nil
+}
+
+func deriveKey(kdf pkix.AlgorithmIdentifier, password []byte, keySize int) ([]byte, error) {
+	switch {
+	case kdf.Algorithm.Equal(oidPBKDF2):
+		var params pbkdf2Params
+		if err := unmarshalAsn1(kdf.Parameters.FullBytes, &params); err != nil {
+			return nil, fmt.Errorf("%w, invalid PBKDF2 parameters: %v", ErrKeyFormat, err)
+		}
+		if params.KeyLength != 0 && params.KeyLength != keySize {
+			return nil, fmt.Errorf("%w, invalid PBKDF2 key length %d", ErrKeyFormat, params.KeyLength)
+		}
+		// HMAC-SHA1 is the default PRF.
+		var prf func() hash.Hash = crypto.SHA1.New
+		if len(params.PRF.Algorithm) > 0 {
+			prf = nil
+			for h, oid := range pbePRFs {
+				if oid.Equal(params.PRF.Algorithm) {
+					prf = h.New
+				}
+			}
+			if prf == nil {
+				return nil, fmt.Errorf("%w, unsupported PBKDF2 PRF %v", ErrKeyFormat, params.PRF.Algorithm)
+			}
+		}
+		if err := checkPBKDF2Params(params.IterationCount); err != nil {
+			return nil, fmt.Errorf("%w, %v", ErrKeyFormat, err)
+		}
+		return pbkdf2.Key(prf, string(password), params.Salt, params.IterationCount, keySize)
+	case kdf.Algorithm.Equal(oidScrypt):
+		var params scryptParams
+		if err := unmarshalAsn1(kdf.Parameters.FullBytes, &params); err != nil {
+			return nil, fmt.Errorf("%w, invalid scrypt parameters: %v", ErrKeyFormat, err)
+		}
+		if params.KeyLength != 0 && params.KeyLength != keySize {
+			return nil, fmt.Errorf("%w, invalid scrypt key length %d", ErrKeyFormat, params.KeyLength)
+		}
+		if err := checkScryptParams(params.CostParameter, params.BlockSize, params.ParallelizationParameter); err != nil {
+			return nil, fmt.Errorf("%w, %v", ErrKeyFormat, err)
+		}
+		return scrypt.Key(password, params.Salt, params.CostParameter, params.BlockSize, params.ParallelizationParameter, keySize)
+	default:
+		return nil, fmt.Errorf("%w, unsupported PBES2 KDF %v", ErrKeyFormat, kdf.Algorithm)
+	}
+}
+
+func checkPBKDF2Params(iterations int) error {
+	if iterations < 1 || iterations > maxPBKDF2Iterations {
+		return fmt.Errorf("PBKDF2 iteration count %d is out of range [1, %d]", iterations, maxPBKDF2Iterations)
+	}
+	return nil
+}
+
+func checkScryptParams(n, r, p int) error {
+	switch {
+	case n < 2 || n > maxScryptN || n&(n-1) != 0:
+		return fmt.Errorf("scrypt N %d is not a power of 2 in range [2, %d]", n, maxScryptN)
+	case r < 1 || r > maxScryptR:
+		return fmt.Errorf("scrypt r %d is out of range [1, %d]", r, maxScryptR)
+	case p < 1 || p > maxScryptP:
+		return fmt.Errorf("scrypt p %d is out of range [1, %d]", p, maxScryptP)
+	case n*r > maxScryptNR:
+		return fmt.Errorf("scrypt N*r %d is larger than %d", n*r, maxScryptNR)
+	}
+	return nil
+}
+
+// Marshal v to be used as the Parameters of a pkix.AlgorithmIdentifier.
+func marshalAsn1Value(v interface{}) (asn1.RawValue, error) {
+	b, err := asn1.Marshal(v)
+	if err != nil {
+		return asn1.RawValue{}, err
+	}
+	return asn1.RawValue{FullBytes: b}, nil
+}
+
+// Unmarshal der to v, trailing data is an error.
+func unmarshalAsn1(der []byte, v interface{}) error {
+	rest, err := asn1.Unmarshal(der, v)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return errors.New("trailing data")
+	}
+	return nil
+}
+
+func pkcs7Pad(data []byte, blockSize int) []byte {
+	n := blockSize - len(data)%blockSize
+	padded := make([]byte, len(data)+n)
+	copy(padded, data)
+	for i := len(data); i < len(padded); i++ {
+		padded[i] = byte(n)
+	}
+	return padded
+}
+
+func pkcs7Unpad(data []byte, blockSize int) ([]byte, error) {
+	if len(data) == 0 || len(data)%blockSize != 0 {
+		return nil, errors.New("invalid padding")
+	}
+	n := int(data[len(data)-1])
+	if n == 0 || n > blockSize {
+		return nil, errors.New("invalid padding")
+	}
+	for _, b := range data[len(data)-n:] {
+		if int(b) != n {
+			return nil, errors.New("invalid padding")
+		}
+	}
+	return data[:len(data)-n], nil
+}
@@ -0,0 +1,168 @@
+package rsacrypto
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+)
+
+// PEM block types.
+const (
+	PEMTypePublicKey     = "PUBLIC KEY"
+	PEMTypeRSAPublicKey  = "RSA PUBLIC KEY"
+	PEMTypePrivateKey    = "PRIVATE KEY"
+	PEMTypeRSAPrivateKey = "RSA PRIVATE KEY"
+	PEMTypeCertificate   = "CERTIFICATE"
+)
+
+// Describes the PEM block a key was loaded from.
+type PEMInfo struct {
+	Type   string    // The block type, e.g. "RSA PRIVATE KEY".
+	Format KeyFormat // The DER format of the block content.
+}
+
+// Parse rsa public key from PEM data.
+//		Blocks of type PUBLIC KEY, RSA PUBLIC KEY, CERTIFICATE, PRIVATE KEY, RSA PRIVATE KEY and OPENSSH PRIVATE KEY are accepted,
+//		the public key of a private key block is returned.
+//		Any data before the first block and blocks of other types are skipped.
+//		About PEM, @see https://tools.ietf.org/html/rfc7468 .
+func ParsePEMPublicKey(data []byte) (key *rsa.PublicKey, info *PEMInfo, err error) {
+	rest := data
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, nil, fmt.Errorf("%w, no public key found in PEM data", ErrKeyFormat)
+		}
+
+		var format KeyFormat
+		switch block.Type {
+		case PEMTypePublicKey, PEMTypeRSAPublicKey:
+			key, format, err = parseDERPublicKey(block.Bytes)
+		case PEMTypePrivateKey, PEMTypeRSAPrivateKey:
+			if isLegacyEncryptedPEMBlock(block) {
+				return nil, nil, fmt.Errorf("%w, the private key is encrypted", ErrPassword)
+			}
+			var priv *rsa.PrivateKey
+			priv, format, err = parseDERPrivateKey(block.Bytes)
+			if err == nil {
+				key = &priv.PublicKey
+			}
+		case PEMTypeCertificate:
+			key, err = parseCertificatePublicKey(block.Bytes)
+			format = FormatX509
+		case PEMTypeOpenSSHPrivateKey:
+			key, err = parseSSHPrivateKeyBlockPublicKey(block)
+			format = FormatOpenSSH
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		return key, &PEMInfo{Type: block.Type, Format: format}, nil
+	}
+}
+
+// Parse rsa private key from PEM data.
+//		Blocks of type PRIVATE KEY, RSA PRIVATE KEY and OPENSSH PRIVATE KEY are accepted.
+//		Any data before the first block and blocks of other types, e.g. certificates, are skipped.
+//		An encrypted block returns an error matching ErrPassword, @see ParseEncryptedPEMPrivateKey .
+func ParsePEMPrivateKey(data []byte) (key *rsa.PrivateKey, info *PEMInfo, err error) {
+	return parsePEMPrivateKey(data, nil)
+}
+
+// Same as ParsePEMPrivateKey, but encrypted blocks are decrypted with password.
+//		ENCRYPTED PRIVATE KEY (encrypted PKCS8) blocks are accepted too, as well as
+//		legacy OpenSSL blocks with "Proc-Type: 4,ENCRYPTED" and "DEK-Info" headers
+//		(DES-EDE3-CBC, AES-128-CBC, AES-192-CBC and AES-256-CBC),
+//		and OPENSSH PRIVATE KEY blocks encrypted with bcrypt-pbkdf.
+func ParseEncryptedPEMPrivateKey(data []byte, password []byte) (key *rsa.PrivateKey, info *PEMInfo, err error) {
+	if password == nil {
+		password = []byte{}
+	}
+	return parsePEMPrivateKey(data, password)
+}
+
+// A nil password means no password is given.
+func parsePEMPrivateKey(data []byte, password []byte) (key *rsa.PrivateKey, info *PEMInfo, err error) {
+	rest := data
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, nil, fmt.Errorf("%w, no private key found in PEM data", ErrKeyFormat)
+		}
+
+		switch block.Type {
+		case PEMTypePrivateKey, PEMTypeRSAPrivateKey:
+			der := block.Bytes
+			if isLegacyEncryptedPEMBlock(block) {
+				if password == nil {
+					return nil, nil, fmt.Errorf("%w, the private key is encrypted", ErrPassword)
+				}
+				der, err = decryptLegacyPEMBlock(block, password)
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+			key, format, err := parseDERPrivateKey(der)
+			if err != nil {
+				return nil, nil, err
+			}
+			return key, &PEMInfo{Type: block.Type, Format: format}, nil
+		case PEMTypeEncryptedPrivateKey:
+			if password == nil {
+				return nil, nil, fmt.Errorf("%w, the private key is encrypted", ErrPassword)
+			}
+			key, err := ParseEncryptedPrivateKey(block.Bytes, password)
+			if err != nil {
+				return nil, nil, err
+			}
+			return key, &PEMInfo{Type: block.Type, Format: FormatPKCS8}, nil
+		case PEMTypeOpenSSHPrivateKey:
+			key, err := parseSSHPrivateKeyBlock(block, password)
+			if err != nil {
+				return nil, nil, err
+			}
+			return key, &PEMInfo{Type: block.Type, Format: FormatOpenSSH}, nil
+		}
+	}
+}
+
+func parseCertificatePublicKey(der []byte) (*rsa.PublicKey, error) {
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	return certificatePublicKey(cert)
+}
+
+// Marshal rsa public key to a PEM block in the PKIX ("PUBLIC KEY") or PKCS1 ("RSA PUBLIC KEY") format.
+func MarshalPEMPublicKey(key *rsa.PublicKey, format KeyFormat) (data []byte, err error) {
+	der, err := MarshalDERPublicKey(key, format)
+	if err != nil {
+		return nil, err
+	}
+
+	blockType := PEMTypePublicKey
+	if format == FormatPKCS1 {
+		blockType = PEMTypeRSAPublicKey
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), nil
+}
+
+// Marshal rsa private key to a PEM block in the PKCS8 ("PRIVATE KEY") or PKCS1 ("RSA PRIVATE KEY") format.
+func MarshalPEMPrivateKey(key *rsa.PrivateKey, format KeyFormat) (data []byte, err error) {
+	der, err := MarshalDERPrivateKey(key, format)
+	if err != nil {
+		return nil, err
+	}
+
+	blockType := PEMTypePrivateKey
+	if format == FormatPKCS1 {
+		blockType = PEMTypeRSAPrivateKey
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), nil
+}
@@ -0,0 +1,111 @@
+package rsacrypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"strings"
+)
+
+// A cipher of the legacy OpenSSL PEM encryption.
+type legacyPEMCipher struct {
+	keySize   int
+	newCipher func(key []byte) (cipher.Block, error)
+}
+
+var legacyPEMCiphers = map[string]legacyPEMCipher{
+	"DES-EDE3-CBC": {24, des.NewTripleDESCipher},
+	"AES-128-CBC":  {16, aes.NewCipher},
+	"AES-192-CBC":  {24, aes.NewCipher},
+	"AES-256-CBC":  {32, aes.NewCipher},
+}
+
+// Reports whether the block is encrypted with the legacy OpenSSL PEM encryption,
+// @see https://tools.ietf.org/html/rfc1421#section-4.6.1 .
+func isLegacyEncryptedPEMBlock(block *pem.Block) bool {
+	return strings.Contains(block.Headers["Proc-Type"], "ENCRYPTED")
+}
+
+// Decrypt a block with the "Proc-Type: 4,ENCRYPTED" and "DEK-Info" headers written by OpenSSL.
+//		An incorrect password returns an error matching ErrPassword,
+//		while corrupt headers or data return an error matching ErrKeyFormat.
+func decryptLegacyPEMBlock(block *pem.Block, password []byte) ([]byte, error) {
+	dekInfo, ok := block.Headers["DEK-Info"]
+	if !ok {
+		return nil, fmt.Errorf("%w, missing DEK-Info header", ErrKeyFormat)
+	}
+	name, ivHex, ok := strings.Cut(dekInfo, ",")
+	if !ok {
+		return nil, fmt.Errorf("%w, invalid DEK-Info header %q", ErrKeyFormat, dekInfo)
+	}
+	c, ok := legacyPEMCiphers[strings.ToUpper(strings.TrimSpace(name))]
+	if !ok {
+		return nil, fmt.Errorf("%w, unsupported PEM cipher %q", ErrKeyFormat, name)
+	}
+	iv, err := hex.DecodeString(strings.TrimSpace(ivHex))
+	if err != nil {
+		return nil, fmt.Errorf("%w, invalid DEK-Info IV: %v", ErrKeyFormat, err)
+	}
+
+	key := bytesToKey(password, iv[:min(len(iv), 8)], c.keySize)
+	b, err := c.newCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != b.BlockSize() {
+		return nil, fmt.Errorf("%w, invalid DEK-Info IV size %d", ErrKeyFormat, len(iv))
+	}
+	if len(block.Bytes) == 0 || len(block.Bytes)%b.BlockSize() != 0 {
+		return nil, fmt.Errorf("%w, encrypted data is not a multiple of the block size", ErrKeyFormat)
+	}
+
+	plain := make([]byte, len(block.Bytes))
+	cipher.NewCBCDecrypter(b, iv).CryptBlocks(plain, block.Bytes)
+	plain, err = pkcs7Unpad(plain, b.BlockSize())
+	if err != nil || !isDERSequence(plain) {
+		return nil, ErrPassword
+	}
+	return plain, nil
+}
+
+// The key derivation of OpenSSL EVP_BytesToKey with MD5 and one iteration.
+func bytesToKey(password []byte, salt []byte, keySize int) []byte {
+	h := md5.New()
+	key := make([]byte, 0, keySize+md5.Size)
+	var digest []byte
+	for len(key) < keySize {
+		h.Reset()
+		h.Write(digest)
+		h.Write(password)
+		h.Write(salt)
+		digest = h.Sum(digest[:0])
+		key = append(key, digest...)
+	}
+	return key[:keySize]
+}
+
+// Reports whether data starts with a DER SEQUENCE header covering exactly all of data.
+//		Decrypting with a wrong password garbles the first block, so the header is
+//		almost never valid, while corrupt data usually leaves it intact.
+func isDERSequence(data []byte) bool {
+	if len(data) < 2 || data[0] != 0x30 {
+		return false
+	}
+	length, headerSize := int(data[1]), 2
+	if length&0x80 != 0 {
+		n := length & 0x7f
+		if n == 0 || n > 4 || len(data) < 2+n {
+			return false
+		}
+		length = 0
+		for _, b := range data[2 : 2+n] {
+			length = length<<8 | int(b)
+		}
+		headerSize += n
+	}
+	return headerSize+length == len(data)
+}
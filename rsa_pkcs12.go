@@ -0,0 +1,60 @@
+package rsacrypto
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// The content of a PKCS12 (.p12 / .pfx) keystore, @see LoadPKCS12 .
+type PKCS12Keystore struct {
+	PrivateKey  *RSAPrivateKey
+	PublicKey   *RSAPublicKey // The public key of PrivateKey.
+	Certificate *x509.Certificate
+	CACerts     []*x509.Certificate // The rest of the certificate chain.
+}
+
+// Load the rsa private key and certificate chain of a PKCS12 keystore,
+// e.g. a .p12 file written by Java keytool or a .pfx file exported by Windows.
+//		The keystore must hold exactly one private key, the first certificate is taken as its certificate.
+//		An incorrect password returns an error matching ErrPassword.
+func LoadPKCS12(data []byte, password []byte) (keystore *PKCS12Keystore, err error) {
+	key, cert, caCerts, err := pkcs12.DecodeChain(data, string(password))
+	if errors.Is(err, pkcs12.ErrIncorrectPassword) {
+		return nil, ErrPassword
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w, invalid PKCS12 data: %v", ErrKeyFormat, err)
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w, PKCS12 private key is %T", ErrNotRSAKey, key)
+	}
+	if !rsaKey.PublicKey.Equal(cert.PublicKey) {
+		return nil, fmt.Errorf("%w, the PKCS12 certificate does not hold the private key %s", ErrCertificate, KeyID(&rsaKey.PublicKey))
+	}
+	return &PKCS12Keystore{
+		PrivateKey:  NewRSAPrivateKey().SetKey(rsaKey),
+		PublicKey:   NewRSAPublicKey().SetKey(&rsaKey.PublicKey),
+		Certificate: cert,
+		CACerts:     caCerts,
+	}, nil
+}
+
+// Marshal rsa private key and its certificate chain to a PKCS12 keystore.
+//		The key and certificates are encrypted with AES-256-CBC and PBKDF2-HMAC-SHA256,
+//		and the integrity is protected by HMAC-SHA256, as by OpenSSL 3 and Java 12+.
+//		A password is required by most readers, use a high entropy one.
+func MarshalPKCS12(key *rsa.PrivateKey, cert *x509.Certificate, caCerts []*x509.Certificate, password []byte) (data []byte, err error) {
+	certKey, err := certificatePublicKey(cert)
+	if err != nil {
+		return nil, err
+	}
+	if !certKey.Equal(&key.PublicKey) {
+		return nil, fmt.Errorf("%w, the certificate does not hold the private key %s", ErrCertificate, KeyID(&key.PublicKey))
+	}
+	return pkcs12.Modern.Encode(key, cert, caCerts, string(password))
+}
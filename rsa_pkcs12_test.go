@@ -0,0 +1,96 @@
+package rsacrypto
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// testKeys[1] and a self-signed certificate exported by: openssl pkcs12 -export -passout pass:rsacrypto
+const testPKCS12 = `
+MIIGjwIBAzCCBkUGCSqGSIb3DQEHAaCCBjYEggYyMIIGLjCCAvIGCSqGSIb3DQEHBqCCAuMwggLf
+AgEAMIIC2AYJKoZIhvcNAQcBMFcGCSqGSIb3DQEFDTBKMCkGCSqGSIb3DQEFDDAcBAj0faDlxXm7
+hwICCAAwDAYIKoZIhvcNAgkFADAdBglghkgBZQMEASoEEH1TMwQvDQgDRU3hvlAL0VqAggJwfY0x
+ouOPxjUM1Qi2mzhlEfLw6TaSP0WufzpfUkVR0VodGKFOFJEIIa1dLztt5800bZIkd1WgiPmbqyig
+UM4CQkVnabOdvamZIIEhGoGmbWOp3JSuPSz4BvZmYFB/gl+pVrI/qylTqRqe65JhOapwg1s4RrIF
+iEEoiBvjVUlZQS0Te/ZBqU2S3momzybaJt/ZjfinutjcwZwUp7Um/UodID9N1AscUtoinYXIeLhJ
+wnIkj62t4bbyu8uMHG9PDmT+K3QybT3aNDV9ScRb1QKWed4kKxSiYO16IrSFaZwiVzYL7bNRYmpL
+U/W+9vpzmoJt1LnxlK4ZWJMvaRXQZfEkgmTMI9KWP21v50dAcNa9a6nTFLcNpWn8+mf4g6O6C74p
+kez5eU75nCgPnixjXnHqu/2NSDFv/7i/XeXAq3O1gcVbj8QgKSv+rZP5Ng02RoYGIHaP0opFsu9D
+81ipPBRDw8vP3BLy4aCoBaMduxchukwA+UUdp8Um5VUDS5KpnMWoZiMUudHZuPHcsNuRg/gbW0FU
+GuhDZ7D8zzkQMwO3t7jbR2BJC7Bu9gPlPDDWPXrCqWDN3TUNVaiLzm0VuQAwv0hXEofPgkS54LUT
+Y3nQz0saXXNh0Nt2iDfSsea2m5wbBeqPoLV/O/J1yvfY1Po8VePjz9LPwfQhTijU8eiNfMxmdQ2O
+wqI00j18rgvkSrHYqQ4+h08dlemukYnonJZmqWn1znWGVUb2i/+cQ5BZ6EpyEEWqBf/LTV/Omem8
+DRBbQZFLmjrpeVDlD+tANoACWaOqePAFR3qGykX6UQlB/Pm/Or8iBNqJzspvcnSC8/DGMIIDNAYJ
+KoZIhvcNAQcBoIIDJQSCAyEwggMdMIIDGQYLKoZIhvcNAQwKAQKgggLhMIIC3TBXBgkqhkiG9w0B
+BQ0wSjApBgkqhkiG9w0BBQwwHAQIbTgF5NILOIICAggAMAwGCCqGSIb3DQIJBQAwHQYJYIZIAWUD
+BAEqBBA3kCSL+1G/FRKUeU/pUfGYBIICgPXy9B24t+8ffauft7Mx3c6AdLw6bqVS38+qvPH78WrE
+3nq0/1oM42qh56751lfNLAbEdP0X0apf56S5UOIxxpx54ys6KGFyOp2kDmH1Emk/h7sDtjjigH8w
+5fPkLlT4l/neOCPphlZnRleQ98w4nFUEwf82yZblwNiXJbTeHyd74aO6F+LfWTVZHyhanCEm88xy
+txOVSAVMmJYJwQ49iCOpkKXJGfg0C7QFAU/1Co3ZEVMdGgLdZVsjn8tUBh/Pj6HiwhiKwVBJ3W87
+OAN2yR4nzXSlL8dPDWn/n9V8/J76jObpX03O2vN2Fx4LvZhWNxC7A+v8+3JvNwGiDGJ5ylA7cpYr
+6m7ZnY5SdYTplLFjdReLhl4xMZkQUAz8BPvMXVLMo7IKSYylhO1lMuY44TEQJuXnjxpbjXnD0EI1
+ihhC3dyVCRpa60qJ51u4gfTfCNB97WjsUgDJPUcvMQ/00gxn6f15Hg1tKT6xOsHQd7XgZNJbIyEs
+QtxGDDBmmnyawiuKnp2+ZnO4Xpk6x7yF1grIlM+ZjM4xBNn4JsftLMHcmHLgBqv8Yckys35CcIbd
+U+u5gNmrigBwgAUE84odzmqOYgm4FxnX+MquW57RLJajFvf56Uz0uoyA7+t7uXeLqBRfIWfO8sPz
+JMKDjKfl3sG9bAq4/y6Q3ZbnbFQjxDW03D+f8tbgNdGdwL4BdMJfZ3cefl7gNuxDjtSVPFcU2SlF
+PbJmyiUrVLR9p/ECMZf4XhVzmloNdMwTf3Y/KYVPQvw/DgyE6bO+0jjXku3weojhXVPXla4oZWp6
+4WOoqS9HhJb/Ls9cKxFuiXVXRlThw/7BPl9sQOShgviKyTJteWcxJTAjBgkqhkiG9w0BCRUxFgQU
+UauS/BexyKIWzx8ZsQsrDOn171UwQTAxMA0GCWCGSAFlAwQCAQUABCBWXMirbkB4JnyU//5jVeNj
+008TuApzhMvh8zY/pZtTsAQIOCyIMlt0jf8CAggA
+`
+
+func TestLoadPKCS12(t *testing.T) {
+	expected, err := ParseEncodedPrivateKey(testKeys[1].PrivateKey, nil)
+	assert.Nil(t, err)
+	data, err := base64.StdEncoding.DecodeString(testPKCS12)
+	assert.Nil(t, err)
+
+	keystore, err := LoadPKCS12(data, []byte("rsacrypto"))
+	assert.Nil(t, err)
+	assert.Equal(t, expected.D, keystore.PrivateKey.privateKey.D)
+	assert.Equal(t, "rsacrypto test", keystore.Certificate.Subject.CommonName)
+	assert.Empty(t, keystore.CACerts)
+
+	cipher, err := keystore.PublicKey.Encrypt([]byte("A short message"))
+	assert.Nil(t, err)
+	plain, err := keystore.PrivateKey.Decrypt(cipher)
+	assert.Nil(t, err)
+	assert.Equal(t, "A short message", string(plain))
+
+	_, err = LoadPKCS12(data, []byte("wrong password"))
+	assert.ErrorIs(t, err, ErrPassword)
+	_, err = LoadPKCS12([]byte("not a keystore"), []byte("rsacrypto"))
+	assert.ErrorIs(t, err, ErrKeyFormat)
+}
+
+func TestRSAPrivateKey_MarshalPKCS12(t *testing.T) {
+	caKey, err := NewRSAPrivateKey().SetEncodedKey(testKeys[0].PrivateKey, nil)
+	assert.Nil(t, err)
+	caDer, err := caKey.CreateSelfSignedCertificate(&x509.Certificate{Subject: pkix.Name{CommonName: "rsacrypto test CA"}, IsCA: true})
+	assert.Nil(t, err)
+	ca, err := x509.ParseCertificate(caDer)
+	assert.Nil(t, err)
+
+	data, err := base64.StdEncoding.DecodeString(testPKCS12)
+	assert.Nil(t, err)
+	keystore, err := LoadPKCS12(data, []byte("rsacrypto"))
+	assert.Nil(t, err)
+
+	data, err = keystore.PrivateKey.MarshalPKCS12(keystore.Certificate, []*x509.Certificate{ca}, []byte("another password"))
+	assert.Nil(t, err)
+	loaded, err := LoadPKCS12(data, []byte("another password"))
+	assert.Nil(t, err)
+	assert.Equal(t, keystore.PrivateKey.privateKey.D, loaded.PrivateKey.privateKey.D)
+	assert.Equal(t, keystore.Certificate.Raw, loaded.Certificate.Raw)
+	assert.Len(t, loaded.CACerts, 1)
+	assert.Equal(t, ca.Raw, loaded.CACerts[0].Raw)
+
+	// The certificate must hold the key.
+	_, err = caKey.MarshalPKCS12(keystore.Certificate, nil, []byte("rsacrypto"))
+	assert.ErrorIs(t, err, ErrCertificate)
+	_, err = NewRSAPrivateKey().MarshalPKCS12(keystore.Certificate, nil, []byte("rsacrypto"))
+	assert.ErrorIs(t, err, ErrNoKey)
+}
@@ -0,0 +1,84 @@
+package rsacrypto
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"fmt"
+	"math/big"
+)
+
+// Encrypt with the private key using PKCS1 v1.5 type 1 padding, chunked like RSAEncrypter.Encrypt ,
+// so the data could be recovered by anyone with the public key, @see PublicDecrypt .
+//		It is the same as openssl_private_encrypt of PHP, or Cipher.ENCRYPT_MODE with a private key in Java.
+//		It provides no confidentiality, use Sign for signatures.
+func PrivateEncrypt(key *rsa.PrivateKey, plain []byte) (cipher []byte, err error) {
+	return privateEncrypt(context.Background(), key, plain, 0)
+}
+
+// Decrypt data encrypted by PrivateEncrypt with the public key.
+//		It is the same as openssl_public_decrypt of PHP, or Cipher.DECRYPT_MODE with a public key in Java.
+func PublicDecrypt(key *rsa.PublicKey, cipher []byte) (plain []byte, err error) {
+	return publicDecrypt(context.Background(), key, cipher, 0)
+}
+
+func privateEncrypt(ctx context.Context, key *rsa.PrivateKey, plain []byte, workers int) ([]byte, error) {
+	limit := key.Size() - 11
+	if limit <= 0 {
+		return nil, fmt.Errorf("%w %d, the key is too small", ErrChunkSize, limit)
+	}
+	chunks := split(plain, limit)
+	encryptedChunks, err := processChunks(ctx, chunks, workers, func(_ int, chunk []byte) ([]byte, error) {
+		// Signing without a hash pads the data as is: 0x00 0x01 0xff... 0x00 data.
+		return rsa.SignPKCS1v15(nil, key, crypto.Hash(0), chunk)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bytes.Join(encryptedChunks, nil), nil
+}
+
+func publicDecrypt(ctx context.Context, key *rsa.PublicKey, cipher []byte, workers int) ([]byte, error) {
+	limit := key.Size()
+	if len(cipher)%limit != 0 {
+		return nil, fmt.Errorf("%w, cipher size %d is not a multiple of %d", ErrChunkSize, len(cipher), limit)
+	}
+	chunks := split(cipher, limit)
+	decryptedChunks, err := processChunks(ctx, chunks, workers, func(_ int, chunk []byte) ([]byte, error) {
+		em, err := publicOperation(key, chunk)
+		if err != nil {
+			return nil, err
+		}
+		return unpadPKCS1Type1(em)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bytes.Join(decryptedChunks, nil), nil
+}
+
+// The raw rsa public operation c^e mod n, the result is left padded to the key size.
+func publicOperation(key *rsa.PublicKey, chunk []byte) ([]byte, error) {
+	c := new(big.Int).SetBytes(chunk)
+	if c.Cmp(key.N) >= 0 {
+		return nil, rsa.ErrDecryption
+	}
+	m := c.Exp(c, big.NewInt(int64(key.E)), key.N)
+	return m.FillBytes(make([]byte, key.Size())), nil
+}
+
+// Remove the PKCS1 v1.5 type 1 padding, 0x00 0x01 PS 0x00 data, PS is at least 8 bytes of 0xff.
+func unpadPKCS1Type1(em []byte) ([]byte, error) {
+	if len(em) < 11 || em[0] != 0 || em[1] != 1 {
+		return nil, rsa.ErrDecryption
+	}
+	i := 2
+	for i < len(em) && em[i] == 0xff {
+		i++
+	}
+	if i == len(em) || em[i] != 0 || i-2 < 8 {
+		return nil, rsa.ErrDecryption
+	}
+	return em[i+1:], nil
+}
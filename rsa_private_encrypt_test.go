@@ -0,0 +1,54 @@
+package rsacrypto
+
+import (
+	"bytes"
+	"encoding/base64"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// "A short message" encrypted with testKeys[1] by: openssl pkeyutl -sign -pkeyopt rsa_padding_mode:pkcs1
+const testPrivateEncrypted = "iLXjODxzV2S9FOOrDtZUA0Z8uWvo4ePwmo4wUPPv0+toswTKvJaWLU/8IY9k45wZuLlsn6AwFcDVy4OQ59D7u7hbuIUS+0CKNKcjphHsHNrzwJCm8maBJogTJXJcIhPMZIYZKwNRwNHXh6yjqPIIPDakAEVnGai9l4ZFfWCA+4E="
+
+func TestRSAPrivateKey_PrivateEncrypt(t *testing.T) {
+	privKey, err := NewRSAPrivateKey().SetEncodedKey(testKeys[1].PrivateKey, nil)
+	assert.Nil(t, err)
+	pubKey, err := NewRSAPublicKey().SetEncodedKey(testKeys[1].PublicKey, nil)
+	assert.Nil(t, err)
+
+	// Type 1 padding is deterministic, the same as OpenSSL.
+	cipher, err := privKey.PrivateEncryptAndEncode([]byte("A short message"), base64.StdEncoding)
+	assert.Nil(t, err)
+	assert.Equal(t, testPrivateEncrypted, cipher)
+	plain, err := pubKey.DecodeAndPublicDecrypt(testPrivateEncrypted, base64.StdEncoding)
+	assert.Nil(t, err)
+	assert.Equal(t, "A short message", string(plain))
+
+	// Chunked like Encrypt, with and without workers.
+	long := bytes.Repeat([]byte("A long message. "), 100)
+	for _, workers := range []int{0, 4} {
+		privKey.SetWorkers(workers)
+		pubKey.SetWorkers(workers)
+		encrypted, err := privKey.PrivateEncrypt(long)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(encrypted)%privKey.privateKey.Size())
+		decrypted, err := pubKey.PublicDecrypt(encrypted)
+		assert.Nil(t, err)
+		assert.Equal(t, long, decrypted)
+	}
+
+	// Data encrypted with another key.
+	otherKey, err := NewRSAPrivateKey().SetEncodedKey(testKeys[0].PrivateKey, nil)
+	assert.Nil(t, err)
+	encrypted, err := PrivateEncrypt(otherKey.privateKey, []byte("A short message"))
+	assert.Nil(t, err)
+	_, err = PublicDecrypt(pubKey.publicKey, encrypted[:pubKey.publicKey.Size()])
+	assert.NotNil(t, err)
+	_, err = pubKey.PublicDecrypt(encrypted[:10])
+	assert.ErrorIs(t, err, ErrChunkSize)
+
+	_, err = NewRSAPrivateKey().PrivateEncrypt([]byte("A short message"))
+	assert.ErrorIs(t, err, ErrNoKey)
+	_, err = NewRSAPublicKey().PublicDecrypt(encrypted)
+	assert.ErrorIs(t, err, ErrNoKey)
+}
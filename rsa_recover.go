@@ -0,0 +1,65 @@
+package rsacrypto
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+)
+
+// The DigestInfo of a PKCS1 v1.5 signature, @see https://tools.ietf.org/html/rfc8017#section-9.2 .
+type digestInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	Digest    []byte
+}
+
+var digestAlgorithms = map[string]crypto.Hash{
+	"1.2.840.113549.2.5":      crypto.MD5,
+	"1.3.14.3.2.26":           crypto.SHA1,
+	"1.3.36.3.2.1":            crypto.RIPEMD160,
+	"2.16.840.1.101.3.4.2.4":  crypto.SHA224,
+	"2.16.840.1.101.3.4.2.1":  crypto.SHA256,
+	"2.16.840.1.101.3.4.2.2":  crypto.SHA384,
+	"2.16.840.1.101.3.4.2.3":  crypto.SHA512,
+	"2.16.840.1.101.3.4.2.5":  crypto.SHA512_224,
+	"2.16.840.1.101.3.4.2.6":  crypto.SHA512_256,
+	"2.16.840.1.101.3.4.2.7":  crypto.SHA3_224,
+	"2.16.840.1.101.3.4.2.8":  crypto.SHA3_256,
+	"2.16.840.1.101.3.4.2.9":  crypto.SHA3_384,
+	"2.16.840.1.101.3.4.2.10": crypto.SHA3_512,
+}
+
+// Recover the hash algorithm and digest of a PKCS1 v1.5 signature, the verify-recover operation.
+//		The signature is opened with the public key and its DigestInfo is parsed.
+//		A signature of a raw digest without DigestInfo, e.g. signed with crypto.Hash(0) or MD5SHA1,
+//		returns crypto.Hash(0) and the signed data.
+//		It does not verify data against the signature, use Verify for it.
+func (ver *RSAVerifier) Recover(sign []byte) (hash crypto.Hash, digest []byte, err error) {
+	if len(sign) != ver.publicKey.Size() {
+		return 0, nil, rsa.ErrVerification
+	}
+	em, err := publicOperation(ver.publicKey, sign)
+	if err != nil {
+		return 0, nil, rsa.ErrVerification
+	}
+	payload, err := unpadPKCS1Type1(em)
+	if err != nil {
+		return 0, nil, rsa.ErrVerification
+	}
+
+	info := digestInfo{}
+	rest, err := asn1.Unmarshal(payload, &info)
+	if err != nil || len(rest) > 0 {
+		return 0, payload, nil
+	}
+	oid := info.Algorithm.Algorithm.String()
+	hash, ok := digestAlgorithms[oid]
+	if !ok {
+		return 0, nil, fmt.Errorf("%w, unknown digest algorithm %s in signature", ErrUnsupportedOpts, oid)
+	}
+	if len(info.Digest) != hash.Size() {
+		return 0, nil, fmt.Errorf("%w, %v digest size is %d", rsa.ErrVerification, hash, len(info.Digest))
+	}
+	return hash, info.Digest, nil
+}
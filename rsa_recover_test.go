@@ -0,0 +1,67 @@
+package rsacrypto
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// "A short message" signed with testKeys[1] by: openssl dgst -sha384 -sign
+const testOpenSSLSignSHA384 = "DTxbZWp1Gy1/V9QLYfti7fz8VkaG5XjJARBe97xC9gMTmNUceb0ScwfB5MoF5fKOGzTeL44NGi2XxpYqKvqo3k6akTClMmnQyiAoqIzCLNN97gq/hZbu9OqNI1h//FxSDIA2EcZ1Gp6gvQaGINWWz3Nw1RNIm1stCcrghsKRgz4="
+
+func TestRSAPublicKey_Recover(t *testing.T) {
+	pubKey, err := NewRSAPublicKey().SetEncodedKey(testKeys[1].PublicKey, nil)
+	assert.Nil(t, err)
+	privKey, err := NewRSAPrivateKey().SetEncodedKey(testKeys[1].PrivateKey, nil)
+	assert.Nil(t, err)
+
+	// The hash algorithm is detected from the signature, no signer opts are needed.
+	sign, err := base64.StdEncoding.DecodeString(testOpenSSLSignSHA384)
+	assert.Nil(t, err)
+	hash, digest, err := pubKey.Recover(sign)
+	assert.Nil(t, err)
+	assert.Equal(t, crypto.SHA384, hash)
+	assert.Equal(t, "9bbbb11df632f0294a2afa2eb3930d34f9156f19f38a51a4700ee10286fe4ae1858b4d2b0f37a43c6aebaa16934a8566", hex.EncodeToString(digest))
+
+	for _, h := range []crypto.Hash{crypto.SHA1, crypto.SHA256, crypto.SHA512} {
+		privKey.SetSignerOpts(h)
+		sign, err := privKey.Sign([]byte("A short message"))
+		assert.Nil(t, err)
+		hash, digest, err := NewRSAVerifier(pubKey.publicKey, nil).Recover(sign)
+		assert.Nil(t, err)
+		assert.Equal(t, h, hash)
+		d := h.New()
+		d.Write([]byte("A short message"))
+		assert.Equal(t, d.Sum(nil), digest)
+	}
+
+	// A raw digest signed without DigestInfo.
+	sum := sha256.Sum256([]byte("A short message"))
+	sign, err = rsa.SignPKCS1v15(nil, privKey.privateKey, crypto.Hash(0), sum[:])
+	assert.Nil(t, err)
+	hash, digest, err = pubKey.Recover(sign)
+	assert.Nil(t, err)
+	assert.Equal(t, crypto.Hash(0), hash)
+	assert.Equal(t, sum[:], digest)
+
+	// PSS signatures and signatures of another key can't be recovered.
+	privKey.SetSignerOpts(&rsa.PSSOptions{Hash: crypto.SHA256})
+	sign, err = privKey.Sign([]byte("A short message"))
+	assert.Nil(t, err)
+	_, _, err = pubKey.Recover(sign)
+	assert.ErrorIs(t, err, rsa.ErrVerification)
+	otherKey, err := NewRSAPrivateKey().SetEncodedKey(testKeys[0].PrivateKey, nil)
+	assert.Nil(t, err)
+	otherKey.SetSignerOpts(crypto.SHA256)
+	sign, err = otherKey.Sign([]byte("A short message"))
+	assert.Nil(t, err)
+	_, _, err = pubKey.Recover(sign)
+	assert.ErrorIs(t, err, rsa.ErrVerification)
+
+	_, _, err = NewRSAPublicKey().Recover(sign)
+	assert.ErrorIs(t, err, ErrNoKey)
+}
@@ -0,0 +1,157 @@
+package rsacrypto
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/ssh"
+	"strings"
+)
+
+// The PEM block type of the openssh-key-v1 private key format.
+const PEMTypeOpenSSHPrivateKey = "OPENSSH PRIVATE KEY"
+
+// Describes the authorized_keys line a public key was loaded from.
+type SSHPublicKeyInfo struct {
+	Comment string   // The trailing comment, e.g. "deploy@example.com".
+	Options []string // The leading options, e.g. `from="10.0.0.0/8"`, no-pty.
+}
+
+// Parse rsa public key from an OpenSSH public key, e.g. the content of id_rsa.pub ,
+// or the first ssh-rsa key line of an authorized_keys file.
+//		Empty lines, "#" comment lines and keys of other types, e.g. ssh-ed25519, are skipped.
+//		About the format, @see https://man.openbsd.org/sshd.8#AUTHORIZED_KEYS_FILE_FORMAT .
+func ParseSSHPublicKey(data []byte) (key *rsa.PublicKey, info *SSHPublicKeyInfo, err error) {
+	var skipped []string
+	for {
+		sshKey, comment, options, rest, err := ssh.ParseAuthorizedKey(data)
+		if err != nil && len(skipped) > 0 {
+			return nil, nil, fmt.Errorf("%w, OpenSSH key types are %s", ErrNotRSAKey, strings.Join(skipped, ", "))
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w, invalid OpenSSH public key: %v", ErrKeyFormat, err)
+		}
+		if sshKey.Type() != ssh.KeyAlgoRSA {
+			skipped = append(skipped, sshKey.Type())
+			data = rest
+			continue
+		}
+
+		key, err = sshRSAPublicKey(sshKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		return key, &SSHPublicKeyInfo{Comment: comment, Options: options}, nil
+	}
+}
+
+// Marshal rsa public key to an OpenSSH public key line, "options ssh-rsa AAAA... comment\n",
+// the options and comment of info are omitted when empty, info could be nil.
+//		It is the reverse of ParseSSHPublicKey, so the line could be appended to an authorized_keys file.
+func MarshalSSHPublicKey(key *rsa.PublicKey, info *SSHPublicKeyInfo) (data []byte, err error) {
+	if info == nil {
+		info = &SSHPublicKeyInfo{}
+	}
+	for _, option := range info.Options {
+		if option == "" || strings.ContainsAny(option, "\r\n") {
+			return nil, fmt.Errorf("%w, invalid OpenSSH key option %q", ErrUnsupportedOpts, option)
+		}
+	}
+	if strings.ContainsAny(info.Comment, "\r\n") {
+		return nil, fmt.Errorf("%w, invalid OpenSSH key comment %q", ErrUnsupportedOpts, info.Comment)
+	}
+
+	sshKey, err := ssh.NewPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(info.Options) > 0 {
+		data = append([]byte(strings.Join(info.Options, ",")), ' ')
+	}
+	data = append(data, bytes.TrimSuffix(ssh.MarshalAuthorizedKey(sshKey), []byte("\n"))...)
+	if info.Comment != "" {
+		data = append(append(data, ' '), info.Comment...)
+	}
+	return append(data, '\n'), nil
+}
+
+// Marshal rsa private key to an "OPENSSH PRIVATE KEY" PEM block, the format written by ssh-keygen.
+//		A non-empty password encrypts the key with aes256-ctr and bcrypt-pbkdf, like ssh-keygen does.
+//		Such blocks are read by ParsePEMPrivateKey and ParseEncryptedPEMPrivateKey .
+func MarshalSSHPrivateKey(key *rsa.PrivateKey, comment string, password []byte) (data []byte, err error) {
+	var block *pem.Block
+	if len(password) == 0 {
+		block, err = ssh.MarshalPrivateKey(key, comment)
+	} else {
+		block, err = ssh.MarshalPrivateKeyWithPassphrase(key, comment, password)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(block), nil
+}
+
+// Parse an "OPENSSH PRIVATE KEY" block, a nil password means no password is given.
+//		The password is ignored if the key is not encrypted.
+func parseSSHPrivateKeyBlock(block *pem.Block, password []byte) (*rsa.PrivateKey, error) {
+	data := pem.EncodeToMemory(&pem.Block{Type: block.Type, Bytes: block.Bytes})
+	key, err := ssh.ParseRawPrivateKey(data)
+
+	var missingErr *ssh.PassphraseMissingError
+	if errors.As(err, &missingErr) {
+		if password == nil {
+			return nil, fmt.Errorf("%w, the private key is encrypted", ErrPassword)
+		}
+		key, err = ssh.ParseRawPrivateKeyWithPassphrase(data, password)
+		if errors.Is(err, x509.IncorrectPasswordError) {
+			return nil, ErrPassword
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w, invalid OpenSSH private key: %v", ErrKeyFormat, err)
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w, OpenSSH private key is %T", ErrNotRSAKey, key)
+	}
+	return rsaKey, nil
+}
+
+// The public key of an "OPENSSH PRIVATE KEY" block, it is stored unencrypted even for encrypted keys.
+func parseSSHPrivateKeyBlockPublicKey(block *pem.Block) (*rsa.PublicKey, error) {
+	data := pem.EncodeToMemory(&pem.Block{Type: block.Type, Bytes: block.Bytes})
+	key, err := ssh.ParseRawPrivateKey(data)
+
+	var missingErr *ssh.PassphraseMissingError
+	if errors.As(err, &missingErr) && missingErr.PublicKey != nil {
+		return sshRSAPublicKey(missingErr.PublicKey)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w, invalid OpenSSH private key: %v", ErrKeyFormat, err)
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w, OpenSSH private key is %T", ErrNotRSAKey, key)
+	}
+	return &rsaKey.PublicKey, nil
+}
+
+func sshRSAPublicKey(sshKey ssh.PublicKey) (*rsa.PublicKey, error) {
+	if sshKey.Type() != ssh.KeyAlgoRSA {
+		return nil, fmt.Errorf("%w, OpenSSH key type is %s", ErrNotRSAKey, sshKey.Type())
+	}
+	cryptoKey, ok := sshKey.(ssh.CryptoPublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%w, OpenSSH key type is %s", ErrNotRSAKey, sshKey.Type())
+	}
+	key, ok := cryptoKey.CryptoPublicKey().(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%w, OpenSSH key type is %s", ErrNotRSAKey, sshKey.Type())
+	}
+	return key, nil
+}
@@ -0,0 +1,207 @@
+package rsacrypto
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+)
+
+// Encrypts data written to it chunk by chunk, @see RSAEncrypter.NewEncryptWriter .
+type encryptWriter struct {
+	enc    *RSAEncrypter
+	w      io.Writer
+	limit  int
+	buffer []byte
+	err    error
+}
+
+// Create a writer which encrypts the data written to it and writes the cipher to w.
+//		The output is the same as Encrypt, chunks are encrypted as soon as they are full,
+//		so the memory is bounded by one RSA block.
+//		Close must be called to flush the last chunk, it does not close w.
+//		The hybrid and framed modes are not supported, since they need the whole message.
+func (enc *RSAEncrypter) NewEncryptWriter(w io.Writer) (io.WriteCloser, error) {
+	switch enc.opts.(type) {
+	case *HybridOptions, *FramedOptions:
+		return nil, fmt.Errorf("%w, %T does not support streaming", ErrUnsupportedOpts, enc.opts)
+	}
+	limit, err := enc.chunkSize()
+	if err != nil {
+		return nil, err
+	}
+	return &encryptWriter{
+		enc:    enc,
+		w:      w,
+		limit:  limit,
+		buffer: make([]byte, 0, limit),
+	}, nil
+}
+
+func (ew *encryptWriter) Write(p []byte) (n int, err error) {
+	if ew.err != nil {
+		return 0, ew.err
+	}
+	for len(p) > 0 {
+		m := copy(ew.buffer[len(ew.buffer):ew.limit], p)
+		ew.buffer = ew.buffer[:len(ew.buffer)+m]
+		p = p[m:]
+		n += m
+		if len(ew.buffer) == ew.limit {
+			if err := ew.flush(); err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+func (ew *encryptWriter) Close() error {
+	if ew.err != nil {
+		return ew.err
+	}
+	if len(ew.buffer) > 0 {
+		if err := ew.flush(); err != nil {
+			return err
+		}
+	}
+	ew.err = errors.New("rsacrypto: write to closed encrypt writer")
+	return nil
+}
+
+func (ew *encryptWriter) flush() error {
+	encryptedChunk, err := ew.enc.encryptChunk(ew.buffer)
+	if err == nil {
+		_, err = ew.w.Write(encryptedChunk)
+	}
+	if err != nil {
+		ew.err = err
+		return err
+	}
+	ew.buffer = ew.buffer[:0]
+	return nil
+}
+
+// Decrypts data read from the underlying reader block by block, @see RSADecrypter.NewDecryptReader .
+type decryptReader struct {
+	dec   *RSADecrypter
+	r     io.Reader
+	block []byte
+	plain []byte
+	err   error
+	count int // The number of blocks read.
+}
+
+// Create a reader which reads the cipher from r and returns the decrypted data.
+//		The input is the same as Decrypt, one RSA block is decrypted at a time.
+//		The hybrid and framed modes are not supported, since they need the whole message.
+//		Unlike Decrypt with nil options, a hybrid ciphertext is not decrypted but fails with ErrUnsupportedOpts .
+func (dec *RSADecrypter) NewDecryptReader(r io.Reader) (io.Reader, error) {
+	switch dec.opts.(type) {
+	case *HybridOptions, *FramedOptions:
+		return nil, fmt.Errorf("%w, %T does not support streaming", ErrUnsupportedOpts, dec.opts)
+	}
+	return &decryptReader{
+		dec:   dec,
+		r:     r,
+		block: make([]byte, dec.privateKey.Size()),
+	}, nil
+}
+
+func (dr *decryptReader) Read(p []byte) (n int, err error) {
+	for len(dr.plain) == 0 {
+		if dr.err != nil {
+			return 0, dr.err
+		}
+		dr.err = dr.next()
+	}
+	n = copy(p, dr.plain)
+	dr.plain = dr.plain[n:]
+	return n, nil
+}
+
+func (dr *decryptReader) next() error {
+	_, err := io.ReadFull(dr.r, dr.block)
+	if err == io.ErrUnexpectedEOF {
+		return fmt.Errorf("%w, truncated cipher block", ErrChunkSize)
+	}
+	if err != nil {
+		return err
+	}
+
+	dr.count++
+
+	plain, err := dr.dec.decryptChunk(dr.block)
+	// A chunked cipher starts with the hybrid magic by chance once in 2^32, so the header is only checked on failure.
+	if err != nil && dr.count == 1 && dr.dec.opts == nil && hasHybridHeader(dr.block) {
+		return fmt.Errorf("%w, a hybrid ciphertext can not be streamed, use Decrypt", ErrUnsupportedOpts)
+	}
+	if err != nil {
+		return err
+	}
+	dr.plain = plain
+	return nil
+}
+
+// Sign the data read from r until EOF, the data is hashed incrementally instead of loaded into memory.
+//		The sign is the same as Sign of the whole data.
+func (sig *RSASigner) SignReader(r io.Reader) (sign []byte, err error) {
+	h, err := newSignerHash(sig.opts)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(h, r); err != nil {
+		return nil, err
+	}
+	return sig.SignDigest(h.Sum(nil))
+}
+
+// Verify the sign of the data read from r until EOF, the data is hashed incrementally instead of loaded into memory.
+func (ver *RSAVerifier) VerifyReader(r io.Reader, sign []byte) (err error) {
+	h, err := newSignerHash(ver.opts)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(h, r); err != nil {
+		return err
+	}
+	return ver.VerifyDigest(h.Sum(nil), sign)
+}
+
+// Hashes the data written to it for a sign, @see RSASigner.NewSigningWriter .
+type SigningWriter struct {
+	sig *RSASigner
+	w   io.Writer
+	h   hash.Hash
+}
+
+// Create a writer which hashes the data written to it and passes it through to w,
+// e.g. to sign an archive while it is written to a file.
+//		w could be nil if the data is only signed.
+//		Call Sign once all the data is written.
+func (sig *RSASigner) NewSigningWriter(w io.Writer) (*SigningWriter, error) {
+	h, err := newSignerHash(sig.opts)
+	if err != nil {
+		return nil, err
+	}
+	if w == nil {
+		w = io.Discard
+	}
+	return &SigningWriter{
+		sig: sig,
+		w:   w,
+		h:   h,
+	}, nil
+}
+
+// Write p to the underlying writer, only the bytes written are hashed.
+func (sw *SigningWriter) Write(p []byte) (n int, err error) {
+	n, err = sw.w.Write(p)
+	sw.h.Write(p[:n])
+	return n, err
+}
+
+// Sign the data written so far, the same as Sign of the whole data.
+func (sw *SigningWriter) Sign() (sign []byte, err error) {
+	return sw.sig.SignDigest(sw.h.Sum(nil))
+}
@@ -0,0 +1,129 @@
+package rsacrypto
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestRSAEncrypter_NewEncryptWriter(t *testing.T) {
+	testData := []string{
+		``,
+		`A short message`,
+		strings.Repeat(`This is a very very long message. 这是一段很长很长的消息。`, 100),
+	}
+
+	for _, opts := range []EncrypterOpts{nil, &rsa.OAEPOptions{Hash: crypto.SHA256}} {
+		for _, plain := range testData {
+			for _, key := range testKeys {
+				pubKey, err := NewRSAPublicKey().SetEncodedKey(key.PublicKey, nil)
+				assert.Nil(t, err)
+				privKey, err := NewRSAPrivateKey().SetEncodedKey(key.PrivateKey, nil)
+				assert.Nil(t, err)
+				pubKey.SetEncrypterOpts(opts)
+				privKey.SetDecrypterOpts(opts)
+
+				// Write in small pieces.
+				cipher := &bytes.Buffer{}
+				w, err := pubKey.NewEncryptWriter(cipher)
+				assert.Nil(t, err)
+				_, err = io.CopyBuffer(w, strings.NewReader(plain), make([]byte, 7))
+				assert.Nil(t, err)
+				assert.Nil(t, w.Close())
+
+				// Compatible with Decrypt.
+				decrypted, err := privKey.Decrypt(cipher.Bytes())
+				assert.Nil(t, err)
+				assert.Equal(t, plain, string(decrypted))
+
+				// Read in small pieces.
+				r, err := privKey.NewDecryptReader(iotest.OneByteReader(bytes.NewReader(cipher.Bytes())))
+				assert.Nil(t, err)
+				decrypted, err = io.ReadAll(r)
+				assert.Nil(t, err)
+				assert.Equal(t, plain, string(decrypted))
+
+				// Truncated cipher.
+				if cipher.Len() > 0 {
+					r, err = privKey.NewDecryptReader(bytes.NewReader(cipher.Bytes()[:cipher.Len()-1]))
+					assert.Nil(t, err)
+					_, err = io.ReadAll(r)
+					assert.NotNil(t, err)
+				}
+			}
+		}
+	}
+}
+
+func TestRSAEncrypter_NewEncryptWriterHybrid(t *testing.T) {
+	pub, err := ParseEncodedPublicKey(testKeys[0].PublicKey, nil)
+	assert.Nil(t, err)
+	_, err = NewRSAEncrypter(pub, &HybridOptions{}).NewEncryptWriter(io.Discard)
+	assert.NotNil(t, err)
+
+	priv, err := ParseEncodedPrivateKey(testKeys[0].PrivateKey, nil)
+	assert.Nil(t, err)
+	_, err = NewRSADecrypter(priv, &HybridOptions{}).NewDecryptReader(strings.NewReader(""))
+	assert.NotNil(t, err)
+
+	// Decrypt detects a hybrid ciphertext without options, the reader reports it.
+	cipher, err := NewRSAEncrypter(pub, &HybridOptions{}).Encrypt([]byte(`A short message`))
+	assert.Nil(t, err)
+	r, err := NewRSADecrypter(priv, nil).NewDecryptReader(bytes.NewReader(cipher))
+	assert.Nil(t, err)
+	_, err = io.ReadAll(r)
+	assert.ErrorIs(t, err, ErrUnsupportedOpts)
+}
+
+func TestRSASigner_SignReader(t *testing.T) {
+	data := strings.Repeat(`This is a very very long message. 这是一段很长很长的消息。`, 10000)
+
+	for _, opts := range []crypto.SignerOpts{crypto.SHA256, &rsa.PSSOptions{Hash: crypto.SHA512}} {
+		for _, key := range testKeys {
+			privKey, err := NewRSAPrivateKey().SetEncodedKey(key.PrivateKey, nil)
+			assert.Nil(t, err)
+			pubKey, err := NewRSAPublicKey().SetEncodedKey(key.PublicKey, nil)
+			assert.Nil(t, err)
+			privKey.SetSignerOpts(opts)
+			pubKey.SetSignerOpts(opts)
+
+			// Compatible with Sign and Verify.
+			sign, err := privKey.SignReader(iotest.HalfReader(strings.NewReader(data)))
+			assert.Nil(t, err)
+			assert.Nil(t, pubKey.Verify([]byte(data), sign))
+			sign, err = privKey.Sign([]byte(data))
+			assert.Nil(t, err)
+			assert.Nil(t, pubKey.VerifyReader(strings.NewReader(data), sign))
+			assert.NotNil(t, pubKey.VerifyReader(strings.NewReader(data[1:]), sign))
+
+			// The signing writer passes the data through.
+			out := &bytes.Buffer{}
+			w, err := privKey.NewSigningWriter(out)
+			assert.Nil(t, err)
+			_, err = io.CopyBuffer(w, strings.NewReader(data), make([]byte, 7))
+			assert.Nil(t, err)
+			sign, err = w.Sign()
+			assert.Nil(t, err)
+			assert.Equal(t, data, out.String())
+			assert.Nil(t, pubKey.Verify([]byte(data), sign))
+		}
+	}
+
+	privKey, err := NewRSAPrivateKey().SetEncodedKey(testKeys[0].PrivateKey, nil)
+	assert.Nil(t, err)
+	_, err = privKey.SignReader(strings.NewReader(data))
+	assert.ErrorIs(t, err, ErrNoSignerOpts)
+	privKey.SetSignerOpts(crypto.SHA256)
+	_, err = privKey.SignReader(iotest.ErrReader(io.ErrClosedPipe))
+	assert.ErrorIs(t, err, io.ErrClosedPipe)
+	_, err = NewRSASigner(privKey.privateKey, crypto.Hash(0)).NewSigningWriter(nil)
+	assert.ErrorIs(t, err, ErrUnsupportedOpts)
+	_, err = NewRSAPrivateKey().NewSigningWriter(nil)
+	assert.ErrorIs(t, err, ErrNoKey)
+	assert.ErrorIs(t, NewRSAPublicKey().VerifyReader(strings.NewReader(data), nil), ErrNoKey)
+}
@@ -0,0 +1,122 @@
+package rsacrypto
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// The .NET RSAKeyValue XML document, written by RSA.ToXmlString .
+//		Integers are standard base64 encoded big-endian bytes.
+type xmlRSAKeyValue struct {
+	XMLName  xml.Name `xml:"RSAKeyValue"`
+	Modulus  string   `xml:"Modulus"`
+	Exponent string   `xml:"Exponent"`
+	P        string   `xml:"P,omitempty"`
+	Q        string   `xml:"Q,omitempty"`
+	DP       string   `xml:"DP,omitempty"`
+	DQ       string   `xml:"DQ,omitempty"`
+	InverseQ string   `xml:"InverseQ,omitempty"`
+	D        string   `xml:"D,omitempty"`
+}
+
+// Parse rsa public key from a .NET RSAKeyValue XML document, the private members are ignored.
+func ParseXMLPublicKey(data []byte) (key *rsa.PublicKey, err error) {
+	value := &xmlRSAKeyValue{}
+	if err := xml.Unmarshal(data, value); err != nil {
+		return nil, fmt.Errorf("%w, invalid RSAKeyValue: %v", ErrKeyFormat, err)
+	}
+	return value.publicKey()
+}
+
+// Parse rsa private key from a .NET RSAKeyValue XML document, e.g. the output of RSA.ToXmlString(true) .
+//		D is required, @see NewPrivateKeyFromComponents .
+func ParseXMLPrivateKey(data []byte) (key *rsa.PrivateKey, err error) {
+	value := &xmlRSAKeyValue{}
+	if err := xml.Unmarshal(data, value); err != nil {
+		return nil, fmt.Errorf("%w, invalid RSAKeyValue: %v", ErrKeyFormat, err)
+	}
+	pub, err := value.publicKey()
+	if err != nil {
+		return nil, err
+	}
+	if value.D == "" {
+		return nil, fmt.Errorf("%w, RSAKeyValue is not a private key", ErrKeyFormat)
+	}
+
+	components := &PrivateKeyComponents{N: pub.N.Bytes(), E: big.NewInt(int64(pub.E)).Bytes()}
+	err = decodeComponentMembers([]componentMember{
+		{"D", value.D, &components.D},
+		{"P", value.P, &components.P},
+		{"Q", value.Q, &components.Q},
+		{"DP", value.DP, &components.DP},
+		{"DQ", value.DQ, &components.DQ},
+		{"InverseQ", value.InverseQ, &components.QInv},
+	}, decodeXMLInt)
+	if err != nil {
+		return nil, err
+	}
+	return NewPrivateKeyFromComponents(components)
+}
+
+// Marshal rsa public key to a .NET RSAKeyValue XML document, the same as RSA.ToXmlString(false) .
+func MarshalXMLPublicKey(key *rsa.PublicKey) (data []byte, err error) {
+	return xml.Marshal(&xmlRSAKeyValue{
+		Modulus:  encodeXMLInt(key.N, 0),
+		Exponent: encodeXMLInt(big.NewInt(int64(key.E)), 0),
+	})
+}
+
+// Marshal rsa private key to a .NET RSAKeyValue XML document, the same as RSA.ToXmlString(true) .
+//		The private members are padded to the lengths required by RSA.FromXmlString .
+func MarshalXMLPrivateKey(key *rsa.PrivateKey) (data []byte, err error) {
+	if len(key.Primes) != 2 {
+		return nil, fmt.Errorf("%w, multi-prime keys are not supported by RSAKeyValue", ErrKeyFormat)
+	}
+	dp, dq, qinv := crtValues(key)
+	size := key.Size()
+	half := (size + 1) / 2
+	return xml.Marshal(&xmlRSAKeyValue{
+		Modulus:  encodeXMLInt(key.N, 0),
+		Exponent: encodeXMLInt(big.NewInt(int64(key.E)), 0),
+		P:        encodeXMLInt(key.Primes[0], half),
+		Q:        encodeXMLInt(key.Primes[1], half),
+		DP:       encodeXMLInt(dp, half),
+		DQ:       encodeXMLInt(dq, half),
+		InverseQ: encodeXMLInt(qinv, half),
+		D:        encodeXMLInt(key.D, size),
+	})
+}
+
+func (value *xmlRSAKeyValue) publicKey() (*rsa.PublicKey, error) {
+	n, err := decodeXMLInt("Modulus", value.Modulus)
+	if err != nil {
+		return nil, err
+	}
+	e, err := decodeXMLInt("Exponent", value.Exponent)
+	if err != nil {
+		return nil, err
+	}
+	return NewPublicKeyFromComponents(n.Bytes(), e.Bytes())
+}
+
+// Encode i in at least size bytes.
+func encodeXMLInt(i *big.Int, size int) string {
+	return base64.StdEncoding.EncodeToString(i.FillBytes(make([]byte, max(size, (i.BitLen()+7)/8))))
+}
+
+func decodeXMLInt(name string, s string) (*big.Int, error) {
+	// Hand edited documents may wrap the values.
+	s = strings.Join(strings.Fields(s), "")
+	if s == "" {
+		return nil, fmt.Errorf("%w, RSAKeyValue member %s is missing", ErrKeyFormat, name)
+	}
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w, invalid RSAKeyValue member %s: %v", ErrKeyFormat, name, err)
+	}
+	return new(big.Int).SetBytes(b), nil
+}
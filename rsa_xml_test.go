@@ -0,0 +1,96 @@
+package rsacrypto
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+// testKeys[1] public key, as written by RSA.ToXmlString(false) .
+const testXMLPublicKey = `<RSAKeyValue><Modulus>peW01knj4ZLX/HOWj0Azg1q9vgHo6SzxvTwHH+LmlVokdTUNycnOQqtgDySrJ+ZqNLS1RlfB//eqdetCNZ1splnEoNepc7bUP9ANWEJ/wVVXc76LQtp53hiSCTh9gYepHMkvBRVqY6L0ytlqtq04CL0yyDn/YwGIZMocAtciAL8=</Modulus><Exponent>AQAB</Exponent></RSAKeyValue>`
+
+func TestParseXMLPublicKey(t *testing.T) {
+	der, err := base64.StdEncoding.DecodeString(testKeys[1].PublicKey)
+	assert.Nil(t, err)
+	expected, err := ParseDERPublicKey(der)
+	assert.Nil(t, err)
+
+	key, err := ParseXMLPublicKey([]byte(testXMLPublicKey))
+	assert.Nil(t, err)
+	assert.Equal(t, expected, key)
+
+	data, err := MarshalXMLPublicKey(expected)
+	assert.Nil(t, err)
+	assert.Equal(t, testXMLPublicKey, string(data))
+
+	// Indented documents with wrapped values.
+	indented := strings.NewReplacer("<Modulus>", "\n  <Modulus>\n    ", "H+Lm", "H+Lm\n    ", "<Exponent>", "\n  <Exponent>").Replace(testXMLPublicKey)
+	key, err = ParseXMLPublicKey([]byte(indented))
+	assert.Nil(t, err)
+	assert.Equal(t, expected, key)
+
+	_, err = ParseXMLPublicKey([]byte(`<RSAKeyValue><Exponent>AQAB</Exponent></RSAKeyValue>`))
+	assert.ErrorIs(t, err, ErrKeyFormat)
+	for _, invalid := range []string{
+		`<RSAKeyValue><Modulus>AA==</Modulus><Exponent>AQAB</Exponent></RSAKeyValue>`,
+		`<RSAKeyValue><Modulus>Dw==</Modulus><Exponent>AA==</Exponent></RSAKeyValue>`,
+		`<RSAKeyValue><Modulus>Dw==</Modulus><Exponent>AQ==</Exponent></RSAKeyValue>`,
+		`<RSAKeyValue><Modulus>Dw==</Modulus><Exponent>BA==</Exponent></RSAKeyValue>`,
+	} {
+		_, err = ParseXMLPublicKey([]byte(invalid))
+		assert.ErrorIs(t, err, ErrKeyFormat)
+		_, err = NewRSAPublicKey().SetXMLKey([]byte(invalid))
+		assert.ErrorIs(t, err, ErrKeyFormat)
+	}
+	_, err = ParseXMLPublicKey([]byte(`<DSAKeyValue></DSAKeyValue>`))
+	assert.ErrorIs(t, err, ErrKeyFormat)
+	_, err = ParseXMLPrivateKey([]byte(testXMLPublicKey))
+	assert.ErrorIs(t, err, ErrKeyFormat)
+}
+
+func TestRSAPrivateKey_MarshalXMLKey(t *testing.T) {
+	for _, key := range testKeys {
+		privKey, err := NewRSAPrivateKey().SetEncodedKey(key.PrivateKey, nil)
+		assert.Nil(t, err)
+
+		data, err := privKey.MarshalXMLKey()
+		assert.Nil(t, err)
+		parsedKey, err := NewRSAPrivateKey().SetXMLKey(data)
+		assert.Nil(t, err)
+		assert.Equal(t, privKey.privateKey.D, parsedKey.privateKey.D)
+
+		// The public key of a private document.
+		pubKey, err := NewRSAPublicKey().SetXMLKey(data)
+		assert.Nil(t, err)
+		pubData, err := pubKey.MarshalXMLKey()
+		assert.Nil(t, err)
+		assert.NotContains(t, string(pubData), "<D>")
+
+		cipher, err := pubKey.Encrypt([]byte("A short message"))
+		assert.Nil(t, err)
+		plain, err := parsedKey.Decrypt(cipher)
+		assert.Nil(t, err)
+		assert.Equal(t, "A short message", string(plain))
+
+		// Inconsistent CRT values are rejected.
+		start, end := strings.Index(string(data), "<DP>")+len("<DP>"), strings.Index(string(data), "</DP>")
+		tampered := string(data[:start]) + base64.StdEncoding.EncodeToString([]byte{1}) + string(data[end:])
+		_, err = ParseXMLPrivateKey([]byte(tampered))
+		assert.ErrorIs(t, err, ErrKeyFormat)
+
+		// The key is not precomputed by marshalling, the CRT values still match it.
+		bare := &rsa.PrivateKey{PublicKey: privKey.privateKey.PublicKey, D: privKey.privateKey.D, Primes: privKey.privateKey.Primes}
+		data, err = MarshalXMLPrivateKey(bare)
+		assert.Nil(t, err)
+		assert.Nil(t, bare.Precomputed.Dp)
+		_, err = ParseXMLPrivateKey(data)
+		assert.Nil(t, err)
+	}
+
+	_, err := NewRSAPublicKey().MarshalXMLKey()
+	assert.ErrorIs(t, err, ErrNoKey)
+	_, err = NewRSAPrivateKey().MarshalXMLKey()
+	assert.ErrorIs(t, err, ErrNoKey)
+}